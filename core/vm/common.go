@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -65,3 +66,20 @@ func allZero(b []byte) bool {
 	}
 	return true
 }
+
+// allZeroWords is a faster allZero for hot paths, checking eight bytes at a
+// time before falling back to single bytes for the tail.
+func allZeroWords(b []byte) bool {
+	for len(b) >= 8 {
+		if binary.LittleEndian.Uint64(b) != 0 {
+			return false
+		}
+		b = b[8:]
+	}
+	for _, byte := range b {
+		if byte != 0 {
+			return false
+		}
+	}
+	return true
+}
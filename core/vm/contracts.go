@@ -17,11 +17,23 @@
 package vm
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"math/big"
+	"sort"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/blake2b"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"golang.org/x/crypto/ripemd160"
@@ -31,8 +43,8 @@ import (
 // requires a deterministic gas count based on the input size of the Run method of the
 // contract.
 type PrecompiledContract interface {
-	RequiredGas(inputSize int) uint64 // RequiredPrice calculates the contract gas use
-	Run(input []byte) []byte          // Run runs the precompiled contract
+	RequiredGas(input []byte) uint64  // RequiredPrice calculates the contract gas use
+	Run(input []byte) ([]byte, error) // Run runs the precompiled contract
 }
 
 // Precompiled contains the default set of ethereum contracts
@@ -43,39 +55,293 @@ var PrecompiledContracts = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{4}): &dataCopy{},
 }
 
-// RunPrecompile runs and evaluate the output of a precompiled contract defined in contracts.go
-func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
-	gas := p.RequiredGas(len(input))
-	if contract.UseGas(gas) {
-		ret = p.Run(input)
+// PrecompiledContractsHomestead contains the set of ethereum contracts active
+// from Homestead until Byzantium. It matches the Frontier set, but is kept
+// separate so fork selection can name the fork it picks.
+var PrecompiledContractsHomestead = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+}
+
+// PrecompiledContractsByzantium contains the default set of ethereum contracts
+// extended with the modular exponentiation and elliptic curve operations
+// introduced in Byzantium.
+var PrecompiledContractsByzantium = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{},
+	common.BytesToAddress([]byte{6}): &bn256Add{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}): &bn256Pairing{},
+}
+
+// PrecompiledContractsIstanbul contains the Byzantium set of ethereum contracts
+// extended with the BLAKE2 compression function introduced in Istanbul.
+var PrecompiledContractsIstanbul = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{},
+	common.BytesToAddress([]byte{6}): &bn256Add{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}): &bn256Pairing{},
+	common.BytesToAddress([]byte{9}): &blake2F{},
+}
+
+// PrecompiledContractsBerlin contains the Istanbul set of ethereum contracts
+// with the modular exponentiation repriced by EIP-2565.
+var PrecompiledContractsBerlin = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}): &bn256Add{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}): &bn256Pairing{},
+	common.BytesToAddress([]byte{9}): &blake2F{},
+}
+
+// PrecompiledContractsCancun contains the Berlin set of ethereum contracts
+// extended with the KZG point evaluation introduced in Cancun.
+var PrecompiledContractsCancun = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):    &ecrecover{},
+	common.BytesToAddress([]byte{2}):    &sha256hash{},
+	common.BytesToAddress([]byte{3}):    &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):    &dataCopy{},
+	common.BytesToAddress([]byte{5}):    &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}):    &bn256Add{},
+	common.BytesToAddress([]byte{7}):    &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}):    &bn256Pairing{},
+	common.BytesToAddress([]byte{9}):    &blake2F{},
+	common.BytesToAddress([]byte{0x0a}): &kzgPointEvaluation{},
+}
+
+// PrecompiledContractsPrague contains the Cancun set of ethereum contracts
+// extended with the BLS12-381 curve operations of EIP-2537.
+var PrecompiledContractsPrague = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):    &ecrecover{},
+	common.BytesToAddress([]byte{2}):    &sha256hash{},
+	common.BytesToAddress([]byte{3}):    &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):    &dataCopy{},
+	common.BytesToAddress([]byte{5}):    &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}):    &bn256Add{},
+	common.BytesToAddress([]byte{7}):    &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}):    &bn256Pairing{},
+	common.BytesToAddress([]byte{9}):    &blake2F{},
+	common.BytesToAddress([]byte{0x0a}): &kzgPointEvaluation{},
+	common.BytesToAddress([]byte{0x0b}): &bls12381G1Add{},
+	common.BytesToAddress([]byte{0x0d}): &bls12381G1MultiExp{},
+	common.BytesToAddress([]byte{0x0e}): &bls12381G2Add{},
+	common.BytesToAddress([]byte{0x0f}): &bls12381G2Mul{},
+	common.BytesToAddress([]byte{0x10}): &bls12381G2MultiExp{},
+	common.BytesToAddress([]byte{0x11}): &bls12381Pairing{},
+	common.BytesToAddress([]byte{0x12}): &bls12381MapG1{},
+	common.BytesToAddress([]byte{0x13}): &bls12381MapG2{},
+}
+
+// PrecompiledContractsRIP7212 contains the Berlin set of ethereum contracts
+// extended with the secp256r1 signature verification of RIP-7212.
+var PrecompiledContractsRIP7212 = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):       &ecrecover{},
+	common.BytesToAddress([]byte{2}):       &sha256hash{},
+	common.BytesToAddress([]byte{3}):       &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):       &dataCopy{},
+	common.BytesToAddress([]byte{5}):       &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}):       &bn256Add{},
+	common.BytesToAddress([]byte{7}):       &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}):       &bn256Pairing{},
+	common.BytesToAddress([]byte{9}):       &blake2F{},
+	common.BytesToAddress([]byte{1, 0x00}): &p256Verify{},
+}
+
+// PrecompiledContractsEd25519 contains the Berlin set of ethereum contracts
+// extended with a native ed25519 signature verification.
+var PrecompiledContractsEd25519 = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):       &ecrecover{},
+	common.BytesToAddress([]byte{2}):       &sha256hash{},
+	common.BytesToAddress([]byte{3}):       &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):       &dataCopy{},
+	common.BytesToAddress([]byte{5}):       &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}):       &bn256Add{},
+	common.BytesToAddress([]byte{7}):       &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}):       &bn256Pairing{},
+	common.BytesToAddress([]byte{9}):       &blake2F{},
+	common.BytesToAddress([]byte{1, 0x01}): &ed25519Verify{},
+}
+
+// PrecompiledContractsForConfig returns the set of precompiled contracts
+// active under the given chain rules.
+func PrecompiledContractsForConfig(rules params.Rules) map[common.Address]PrecompiledContract {
+	switch {
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	case rules.IsHomestead:
+		return PrecompiledContractsHomestead
+	default:
+		return PrecompiledContracts
+	}
+}
+
+// ActivePrecompiles returns the addresses of the precompiled contracts active
+// under the given chain rules, in ascending order.
+func ActivePrecompiles(rules params.Rules) []common.Address {
+	contracts := PrecompiledContractsForConfig(rules)
+
+	addrs := make(addressesByValue, 0, len(contracts))
+	for addr := range contracts {
+		addrs = append(addrs, addr)
+	}
+	sort.Sort(addrs)
+	return addrs
+}
+
+// addressesByValue implements sort.Interface to order addresses bytewise.
+type addressesByValue []common.Address
+
+func (a addressesByValue) Len() int           { return len(a) }
+func (a addressesByValue) Less(i, j int) bool { return bytes.Compare(a[i][:], a[j][:]) < 0 }
+func (a addressesByValue) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// RefundingPrecompiledContract is an optional extension of the precompiled
+// contract interface for contracts wishing to refund some of the gas used.
+type RefundingPrecompiledContract interface {
+	PrecompiledContract
+
+	// AfterRun is called after a successful Run with the gas charged for it and
+	// returns the amount of gas to refund. Refunds are capped at half the gas used.
+	AfterRun(gasUsed uint64) (refund uint64)
+}
+
+// Named is an optional extension of the precompiled contract interface for
+// contracts with a human readable name, used by tracers and debuggers. Names
+// are metadata only and carry no consensus meaning.
+type Named interface {
+	Name() string
+}
+
+// PrecompileContext carries the execution environment of a precompiled contract
+// call for contracts whose result depends on more than their input.
+type PrecompileContext struct {
+	BlockNumber *big.Int // Number of the block being executed
+	Time        *big.Int // Timestamp of the block being executed
+	ChainId     *big.Int // Chain id of the chain being executed
+
+	Caller common.Address    // Address of the account calling the contract
+	State  StateReaderWriter // State accessible to stateful contracts
+}
+
+// ContextualPrecompiledContract is an optional extension of the precompiled
+// contract interface for contracts requiring the execution context. Such
+// contracts are run through RunWithContext instead of Run.
+type ContextualPrecompiledContract interface {
+	PrecompiledContract
+
+	// RunWithContext runs the precompiled contract within the given context.
+	RunWithContext(input []byte, ctx PrecompileContext) ([]byte, error)
+}
+
+// OnPrecompile, if set, is invoked by RunPrecompiledContract with the address,
+// input and gas cost of every precompiled contract call before it is executed.
+// The address is zero if the contract was not invoked through its code address.
+var OnPrecompile func(addr common.Address, input []byte, gas uint64)
 
-		return ret, nil
+// RunPrecompile runs and evaluate the output of a precompiled contract defined in contracts.go
+func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract, ctx PrecompileContext) (ret []byte, refund uint64, err error) {
+	gas := p.RequiredGas(input)
+	if hook := OnPrecompile; hook != nil {
+		var addr common.Address
+		if contract.CodeAddr != nil {
+			addr = *contract.CodeAddr
+		}
+		hook(addr, input, gas)
+	}
+	if !contract.UseGas(gas) {
+		return nil, 0, ErrOutOfGas
+	}
+	if PrecompileMetrics.Enabled() && contract.CodeAddr != nil {
+		PrecompileMetrics.record(*contract.CodeAddr, gas)
+	}
+	if c, ok := p.(ContextualPrecompiledContract); ok {
+		ctx.Caller = contract.Caller()
+		ret, err = c.RunWithContext(input, ctx)
 	} else {
-		return nil, ErrOutOfGas
+		ret, err = p.Run(input)
 	}
+	if err != nil {
+		return ret, 0, err
+	}
+	if r, ok := p.(RefundingPrecompiledContract); ok {
+		if refund = r.AfterRun(gas); refund > gas/2 {
+			refund = gas / 2
+		}
+	}
+	return ret, refund, nil
+}
+
+// wordGas calculates the gas cost of an input of the given size priced per
+// 32 byte word on top of a base cost. The result saturates at math.MaxUint64
+// so that an overflowing cost reliably fails with ErrOutOfGas.
+func wordGas(size int, perWord, base uint64) uint64 {
+	gas, overflow := math.SafeMul(toWordSize(uint64(size)), perWord)
+	if overflow {
+		return math.MaxUint64
+	}
+	if gas, overflow = math.SafeAdd(gas, base); overflow {
+		return math.MaxUint64
+	}
+	return gas
 }
 
 // ECRECOVER implemented as a native contract
 type ecrecover struct{}
 
-func (c *ecrecover) RequiredGas(inputSize int) uint64 {
+func (c *ecrecover) Name() string { return "ecrecover" }
+
+func (c *ecrecover) RequiredGas(input []byte) uint64 {
 	return params.EcrecoverGas
 }
 
-func (c *ecrecover) Run(in []byte) []byte {
+func (c *ecrecover) Run(in []byte) ([]byte, error) {
 	const ecRecoverInputLength = 128
 
 	in = common.RightPadBytes(in, ecRecoverInputLength)
+	return cachedEcrecover(in), nil
+}
+
+// ecrecoverAddress recovers the address signing the padded ecrecover input,
+// returning nil if the signature is invalid.
+func ecrecoverAddress(in []byte) []byte {
 	// "in" is (hash, v, r, s), each 32 bytes
 	// but for ecrecover we want (r, s, v)
 
+	// v must be exactly 27 or 28, reject anything else before deriving the
+	// recovery id from it
+	if !allZeroWords(in[32:63]) || (in[63] != 27 && in[63] != 28) {
+		log.Trace("ECRECOVER error: v value invalid")
+		return nil
+	}
+	// Zero r or s values are never valid, reject them before allocating
+	if allZeroWords(in[64:96]) || allZeroWords(in[96:128]) {
+		log.Trace("ECRECOVER error: r or s value zero")
+		return nil
+	}
 	r := new(big.Int).SetBytes(in[64:96])
 	s := new(big.Int).SetBytes(in[96:128])
 	v := in[63] - 27
 
 	// tighter sig s values in homestead only apply to tx sigs
-	if !allZero(in[32:63]) || !crypto.ValidateSignatureValues(v, r, s, false) {
-		log.Trace("ECRECOVER error: v, r or s value invalid")
+	if !crypto.ValidateSignatureValues(v, r, s, false) {
+		log.Trace("ECRECOVER error: r or s value invalid")
 		return nil
 	}
 	// v needs to be at the end for libsecp256k1
@@ -93,44 +359,912 @@ func (c *ecrecover) Run(in []byte) []byte {
 // SHA256 implemented as a native contract
 type sha256hash struct{}
 
+func (c *sha256hash) Name() string { return "sha256" }
+
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-//
-// This method does not require any overflow checking as the input size gas costs
-// required for anything significant is so high it's impossible to pay for.
-func (c *sha256hash) RequiredGas(inputSize int) uint64 {
-	return uint64(inputSize+31)/32*params.Sha256WordGas + params.Sha256Gas
+func (c *sha256hash) RequiredGas(input []byte) uint64 {
+	return wordGas(len(input), params.Sha256WordGas, params.Sha256Gas)
 }
-func (c *sha256hash) Run(in []byte) []byte {
+func (c *sha256hash) Run(in []byte) ([]byte, error) {
 	h := sha256.Sum256(in)
-	return h[:]
+	return h[:], nil
+}
+
+// sha256ChunkSize is the number of bytes fed into the hasher at once.
+const sha256ChunkSize = 64 * 1024
+
+// sha256Chunked hashes the input incrementally in fixed size chunks. It yields
+// the same digest as sha256.Sum256 without requiring the whole payload to be
+// handed to the hasher in one go. The precompile itself keeps using Sum256,
+// which does not allocate and is no slower on any input size.
+func sha256Chunked(in []byte) []byte {
+	hasher := sha256.New()
+	for len(in) > sha256ChunkSize {
+		hasher.Write(in[:sha256ChunkSize])
+		in = in[sha256ChunkSize:]
+	}
+	hasher.Write(in)
+	return hasher.Sum(nil)
 }
 
 // RIPMED160 implemented as a native contract
 type ripemd160hash struct{}
 
+func (c *ripemd160hash) Name() string { return "ripemd160" }
+
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-//
-// This method does not require any overflow checking as the input size gas costs
-// required for anything significant is so high it's impossible to pay for.
-func (c *ripemd160hash) RequiredGas(inputSize int) uint64 {
-	return uint64(inputSize+31)/32*params.Ripemd160WordGas + params.Ripemd160Gas
+func (c *ripemd160hash) RequiredGas(input []byte) uint64 {
+	return wordGas(len(input), params.Ripemd160WordGas, params.Ripemd160Gas)
 }
-func (c *ripemd160hash) Run(in []byte) []byte {
+func (c *ripemd160hash) Run(in []byte) ([]byte, error) {
+	return common.LeftPadBytes(ripemd160Sum(in), 32), nil
+}
+
+// ripemd160Sum returns the raw 20 byte RIPEMD-160 digest of the input.
+func ripemd160Sum(in []byte) []byte {
 	ripemd := ripemd160.New()
 	ripemd.Write(in)
-	return common.LeftPadBytes(ripemd.Sum(nil), 32)
+	return ripemd.Sum(nil)
+}
+
+// Ripemd160Raw returns the 20 byte RIPEMD-160 digest of the input, without the
+// left padding to 32 bytes applied by the precompiled contract.
+func Ripemd160Raw(in []byte) []byte {
+	return ripemd160Sum(in)
 }
 
 // data copy implemented as a native contract
 type dataCopy struct{}
 
+func (c *dataCopy) Name() string { return "identity" }
+
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-//
-// This method does not require any overflow checking as the input size gas costs
-// required for anything significant is so high it's impossible to pay for.
-func (c *dataCopy) RequiredGas(inputSize int) uint64 {
-	return uint64(inputSize+31)/32*params.IdentityWordGas + params.IdentityGas
+func (c *dataCopy) RequiredGas(input []byte) uint64 {
+	return wordGas(len(input), params.IdentityWordGas, params.IdentityGas)
+}
+func (c *dataCopy) Run(in []byte) ([]byte, error) {
+	return in, nil
+}
+
+var (
+	big3      = big.NewInt(3)
+	big4      = big.NewInt(4)
+	big7      = big.NewInt(7)
+	big8      = big.NewInt(8)
+	big16     = big.NewInt(16)
+	big64     = big.NewInt(64)
+	big96     = big.NewInt(96)
+	big480    = big.NewInt(480)
+	big1024   = big.NewInt(1024)
+	big3072   = big.NewInt(3072)
+	big199680 = big.NewInt(199680)
+)
+
+var (
+	// modexpMaxLen is the longest base or modulus accepted when run. Longer ones
+	// cost over 3e9 gas, which no block could ever pay for.
+	modexpMaxLen = big.NewInt(1 << 20)
+
+	// modexpMaxExpLen is the longest exponent accepted when run. Longer ones cost
+	// over 1e9 gas, which no block could ever pay for.
+	modexpMaxExpLen = big.NewInt(1 << 32)
+)
+
+var errModexpUnpayable = errors.New("modexp operand lengths exceed payable bounds")
+
+// modexpIntPool holds scratch big integers reused across modexp calls to avoid
+// reallocating the large operands of every exponentiation.
+var modexpIntPool = sync.Pool{
+	New: func() interface{} { return new(big.Int) },
+}
+
+// getModexpInt retrieves a scratch integer from the pool.
+func getModexpInt() *big.Int {
+	return modexpIntPool.Get().(*big.Int)
+}
+
+// putModexpInts zeroes the scratch integers and returns them to the pool. The
+// backing arrays are kept around for reuse, but their contents are cleared so
+// no operand outlives its call.
+func putModexpInts(xs ...*big.Int) {
+	for _, x := range xs {
+		x.SetUint64(0)
+		modexpIntPool.Put(x)
+	}
+}
+
+// modexpLengths retrieves the declared base, exponent and modulus lengths of a
+// modexp input.
+func modexpLengths(input []byte) (baseLen, expLen, modLen *big.Int) {
+	baseLen = new(big.Int).SetBytes(getData(input, common.Big0, common.Big32))
+	expLen = new(big.Int).SetBytes(getData(input, common.Big32, common.Big32))
+	modLen = new(big.Int).SetBytes(getData(input, big64, common.Big32))
+	return baseLen, expLen, modLen
+}
+
+// modexpAdjExpLen calculates the adjusted exponent length of a modexp input,
+// the iteration count both pricings scale the cost of a call by.
+func modexpAdjExpLen(input []byte, baseLen, expLen *big.Int) *big.Int {
+	// Retrieve the head 32 bytes of exp for the adjusted exponent length
+	expHead := new(big.Int).SetBytes(getData(input, new(big.Int).Add(big96, baseLen), math.BigMin(expLen, common.Big32)))
+
+	adjExpLen := new(big.Int)
+	if expLen.Cmp(common.Big32) > 0 {
+		adjExpLen.Sub(expLen, common.Big32)
+		adjExpLen.Mul(adjExpLen, big8)
+	}
+	if bitlen := expHead.BitLen(); bitlen > 0 {
+		adjExpLen.Add(adjExpLen, big.NewInt(int64(bitlen-1)))
+	}
+	return adjExpLen
+}
+
+// bigModExp implements a native big integer exponential modular operation,
+// priced according to EIP-198.
+type bigModExp struct{}
+
+func (c *bigModExp) Name() string { return "modexp" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bigModExp) RequiredGas(input []byte) uint64 {
+	baseLen, expLen, modLen := modexpLengths(input)
+	adjExpLen := modexpAdjExpLen(input, baseLen, expLen)
+
+	// Calculate the multiplication complexity of max(baseLen, modLen)
+	x := math.BigMax(modLen, baseLen)
+	gas := new(big.Int)
+	switch {
+	case x.Cmp(big64) <= 0:
+		gas.Mul(x, x)
+	case x.Cmp(big1024) <= 0:
+		gas.Mul(x, x)
+		gas.Div(gas, big4)
+		gas.Add(gas, new(big.Int).Mul(big96, x))
+		gas.Sub(gas, big3072)
+	default:
+		gas.Mul(x, x)
+		gas.Div(gas, big16)
+		gas.Add(gas, new(big.Int).Mul(big480, x))
+		gas.Sub(gas, big199680)
+	}
+	// Scale it by the iteration count and the divisor
+	gas.Mul(gas, math.BigMax(adjExpLen, common.Big1))
+	gas.Div(gas, new(big.Int).SetUint64(params.ModExpQuadCoeffDiv))
+
+	if gas.BitLen() > 64 {
+		return math.MaxUint64
+	}
+	return gas.Uint64()
+}
+
+func (c *bigModExp) Run(in []byte) ([]byte, error) {
+	return modexp(in)
+}
+
+// bigModexpEIP2565 implements a native big integer exponential modular operation,
+// priced according to EIP-2565.
+type bigModexpEIP2565 struct{}
+
+func (c *bigModexpEIP2565) Name() string { return "modexp" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bigModexpEIP2565) RequiredGas(input []byte) uint64 {
+	baseLen, expLen, modLen := modexpLengths(input)
+	adjExpLen := modexpAdjExpLen(input, baseLen, expLen)
+
+	// Calculate the multiplication complexity: ceil(max(baseLen, modLen) / 8)^2
+	gas := new(big.Int).Set(math.BigMax(modLen, baseLen))
+	gas.Add(gas, big7)
+	gas.Div(gas, big8)
+	gas.Mul(gas, gas)
+
+	// Scale it by the iteration count and the divisor, with a floor of 200 gas
+	gas.Mul(gas, math.BigMax(adjExpLen, common.Big1))
+	gas.Div(gas, big3)
+
+	if gas.BitLen() > 64 {
+		return math.MaxUint64
+	}
+	if gas.Uint64() < 200 {
+		return 200
+	}
+	return gas.Uint64()
+}
+
+func (c *bigModexpEIP2565) Run(in []byte) ([]byte, error) {
+	return modexp(in)
+}
+
+// modexp runs the big integer exponential modular operation shared by both
+// modexp pricings.
+func modexp(in []byte) ([]byte, error) {
+	// "in" is (baseLen, expLen, modLen, base, exp, mod), lengths being 32 bytes
+	baseLen, expLen, modLen := modexpLengths(in)
+
+	// Handle a special case when both the base and mod length is zero
+	if baseLen.Sign() == 0 && modLen.Sign() == 0 {
+		return []byte{}, nil
+	}
+	// The operands are zero padded to their declared lengths, which may exceed the
+	// input by orders of magnitude. Calls through the EVM are charged before being
+	// run so such inputs run out of gas, but refuse lengths which no block could
+	// pay for when called directly.
+	if baseLen.Cmp(modexpMaxLen) > 0 || modLen.Cmp(modexpMaxLen) > 0 || expLen.Cmp(modexpMaxExpLen) > 0 {
+		return nil, errModexpUnpayable
+	}
+	// Retrieve the modulus first. It follows the other operands, so if any of them
+	// is padded past the end of the input the modulus is all padding and zero,
+	// and the operands need not be read at all.
+	var (
+		expOff = new(big.Int).Add(big96, baseLen)
+		modOff = new(big.Int).Add(expOff, expLen)
+
+		mod = getModexpInt().SetBytes(getData(in, modOff, modLen))
+	)
+	defer putModexpInts(mod)
+
+	if mod.Sign() == 0 {
+		// Modulo 0 is undefined, return zero
+		return common.LeftPadBytes([]byte{}, int(modLen.Uint64())), nil
+	}
+	var (
+		base = getModexpInt().SetBytes(getData(in, big96, baseLen))
+		exp  = getModexpInt().SetBytes(getData(in, expOff, expLen))
+	)
+	defer putModexpInts(base, exp)
+
+	// The result is copied out of the pooled integer, so it remains valid after
+	// the scratch values are recycled
+	return common.LeftPadBytes(base.Exp(base, exp, mod).Bytes(), int(modLen.Uint64())), nil
+}
+
+var (
+	// bn256Modulus is the big endian encoding of the bn256 base field modulus.
+	bn256Modulus = common.LeftPadBytes(bn256.P.Bytes(), 32)
+
+	// errBN256NonCanonical is returned if a bn256 point coordinate is not reduced
+	// modulo the base field modulus.
+	errBN256NonCanonical = errors.New("bn256: non-canonical field element")
+)
+
+// bn256Point is a bn256 elliptic curve point on the curve or on its twist.
+type bn256Point interface {
+	Unmarshal(m []byte) ([]byte, error)
+}
+
+// decodeBN256Point unmarshals a binary blob into a bn256 elliptic curve point,
+// rejecting coordinates not below the field modulus instead of reducing them.
+func decodeBN256Point(p bn256Point, blob []byte) error {
+	for i := 0; i+32 <= len(blob); i += 32 {
+		if bytes.Compare(blob[i:i+32], bn256Modulus) >= 0 {
+			return errBN256NonCanonical
+		}
+	}
+	_, err := p.Unmarshal(blob)
+	return err
+}
+
+// newCurvePoint unmarshals a binary blob into a bn256 elliptic curve point,
+// returning it, or an error if the point is invalid.
+func newCurvePoint(blob []byte) (*bn256.G1, error) {
+	p := new(bn256.G1)
+	if err := decodeBN256Point(p, blob); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// newTwistPoint unmarshals a binary blob into a bn256 elliptic curve point over
+// the twist, returning it, or an error if the point is invalid.
+func newTwistPoint(blob []byte) (*bn256.G2, error) {
+	p := new(bn256.G2)
+	if err := decodeBN256Point(p, blob); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// bn256Add implements a native elliptic curve point addition.
+type bn256Add struct{}
+
+func (c *bn256Add) Name() string { return "bn256Add" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bn256Add) RequiredGas(input []byte) uint64 {
+	return params.Bn256AddGas
+}
+
+func (c *bn256Add) Run(in []byte) ([]byte, error) {
+	const bn256AddInputLength = 128
+
+	// "in" is (x1, y1, x2, y2), each 32 bytes
+	in = common.RightPadBytes(in, bn256AddInputLength)
+
+	x, err := newCurvePoint(in[0:64])
+	if err != nil {
+		return nil, err
+	}
+	y, err := newCurvePoint(in[64:128])
+	if err != nil {
+		return nil, err
+	}
+	res := new(bn256.G1)
+	res.Add(x, y)
+	return res.Marshal(), nil
+}
+
+// bn256ScalarMul implements a native elliptic curve scalar multiplication.
+type bn256ScalarMul struct{}
+
+func (c *bn256ScalarMul) Name() string { return "bn256ScalarMul" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bn256ScalarMul) RequiredGas(input []byte) uint64 {
+	return params.Bn256ScalarMulGas
+}
+
+func (c *bn256ScalarMul) Run(in []byte) ([]byte, error) {
+	const bn256ScalarMulInputLength = 96
+
+	// "in" is (x, y, scalar), each 32 bytes
+	in = common.RightPadBytes(in, bn256ScalarMulInputLength)
+
+	p, err := newCurvePoint(in[0:64])
+	if err != nil {
+		return nil, err
+	}
+	res := new(bn256.G1)
+	res.ScalarMult(p, new(big.Int).SetBytes(in[64:96]))
+	return res.Marshal(), nil
+}
+
+var (
+	// true32Byte is returned if the bn256 pairing check succeeds.
+	true32Byte = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+
+	// false32Byte is returned if the bn256 pairing check fails.
+	false32Byte = make([]byte, 32)
+
+	// errBadPairingInput is returned if the bn256 pairing input is invalid.
+	errBadPairingInput = errors.New("bad elliptic curve pairing size")
+)
+
+// bn256Pairing implements a pairing pre-compile for the bn256 curve.
+type bn256Pairing struct{}
+
+func (c *bn256Pairing) Name() string { return "bn256Pairing" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bn256Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bn256PairingBaseGas + uint64(len(input)/192)*params.Bn256PairingPerPointGas
+}
+
+func (c *bn256Pairing) Run(in []byte) ([]byte, error) {
+	cs, ts, err := decodeBn256Pairs(in)
+	if err != nil {
+		return nil, err
+	}
+	if bn256.PairingCheck(cs, ts) {
+		return true32Byte, nil
+	}
+	return false32Byte, nil
+}
+
+// decodeBn256Pairs decodes the points of a bn256 pairing check input.
+func decodeBn256Pairs(in []byte) ([]*bn256.G1, []*bn256.G2, error) {
+	// "in" is a sequence of (G1, G2) pairs, 64 and 128 bytes respectively
+	if len(in)%192 > 0 {
+		return nil, nil, errBadPairingInput
+	}
+	var (
+		cs []*bn256.G1
+		ts []*bn256.G2
+	)
+	for i := 0; i < len(in); i += 192 {
+		c, err := newCurvePoint(in[i : i+64])
+		if err != nil {
+			return nil, nil, err
+		}
+		t, err := newTwistPoint(in[i+64 : i+192])
+		if err != nil {
+			return nil, nil, err
+		}
+		cs = append(cs, c)
+		ts = append(ts, t)
+	}
+	return cs, ts, nil
+}
+
+const (
+	blake2FInputLength        = 213
+	blake2FFinalBlockBytes    = byte(1)
+	blake2FNonFinalBlockBytes = byte(0)
+)
+
+var (
+	errBlake2FInvalidInputLength = errors.New("invalid input length")
+	errBlake2FInvalidFinalFlag   = errors.New("invalid final flag")
+)
+
+// blake2F implements the BLAKE2b compression function F as a native contract.
+type blake2F struct{}
+
+func (c *blake2F) Name() string { return "blake2f" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract,
+// which is one unit per round of the compression function.
+func (c *blake2F) RequiredGas(input []byte) uint64 {
+	// If the input is malformed, we can't calculate the gas, return 0 and let the
+	// actual call choke and fault.
+	if len(input) != blake2FInputLength {
+		return 0
+	}
+	return uint64(binary.BigEndian.Uint32(input[0:4]))
+}
+
+func (c *blake2F) Run(in []byte) ([]byte, error) {
+	// "in" is (rounds, h, m, t0, t1, f), 4, 64, 128, 8, 8 and 1 bytes
+	if len(in) != blake2FInputLength {
+		return nil, errBlake2FInvalidInputLength
+	}
+	if in[212] != blake2FNonFinalBlockBytes && in[212] != blake2FFinalBlockBytes {
+		return nil, errBlake2FInvalidFinalFlag
+	}
+	var (
+		rounds = binary.BigEndian.Uint32(in[0:4])
+		final  = in[212] == blake2FFinalBlockBytes
+
+		h [8]uint64
+		m [16]uint64
+		t [2]uint64
+	)
+	for i := 0; i < 8; i++ {
+		offset := 4 + i*8
+		h[i] = binary.LittleEndian.Uint64(in[offset : offset+8])
+	}
+	for i := 0; i < 16; i++ {
+		offset := 68 + i*8
+		m[i] = binary.LittleEndian.Uint64(in[offset : offset+8])
+	}
+	t[0] = binary.LittleEndian.Uint64(in[196:204])
+	t[1] = binary.LittleEndian.Uint64(in[204:212])
+
+	blake2b.F(&h, m, t, final, rounds)
+
+	output := make([]byte, 64)
+	for i := 0; i < 8; i++ {
+		offset := i * 8
+		binary.LittleEndian.PutUint64(output[offset:offset+8], h[i])
+	}
+	return output, nil
+}
+
+const blobVerifyInputLength = 192 // Max input length for the point evaluation precompile.
+
+var (
+	errBlobVerifyInvalidInputLength = errors.New("invalid input length")
+	errBlobVerifyMismatchedVersion  = errors.New("mismatched versioned hash")
+	errBlobVerifyKZGProof           = errors.New("error verifying kzg proof")
+)
+
+// blobPrecompileReturnValue is the output of a successful point evaluation,
+// the number of field elements per blob followed by the BLS modulus.
+var blobPrecompileReturnValue = append(
+	common.LeftPadBytes(big.NewInt(kzg4844.FieldElementsPerBlob).Bytes(), 32),
+	kzg4844.BLSModulus...,
+)
+
+// kzgPointEvaluation implements the EIP-4844 point evaluation precompile.
+type kzgPointEvaluation struct{}
+
+func (c *kzgPointEvaluation) Name() string { return "kzgPointEvaluation" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *kzgPointEvaluation) RequiredGas(input []byte) uint64 {
+	return params.BlobVerifyGas
+}
+
+func (c *kzgPointEvaluation) Run(in []byte) ([]byte, error) {
+	// "in" is (versioned hash, z, y, commitment, proof), 32, 32, 32, 48 and 48 bytes
+	if len(in) != blobVerifyInputLength {
+		return nil, errBlobVerifyInvalidInputLength
+	}
+	var (
+		point      kzg4844.Point
+		claim      kzg4844.Claim
+		commitment kzg4844.Commitment
+		proof      kzg4844.Proof
+	)
+	copy(point[:], in[32:64])
+	copy(claim[:], in[64:96])
+	copy(commitment[:], in[96:144])
+	copy(proof[:], in[144:192])
+
+	// Make sure the versioned hash matches the commitment
+	if versionedHash := kzg4844.CalcBlobHashV1(&commitment); !bytes.Equal(versionedHash[:], in[:32]) {
+		return nil, errBlobVerifyMismatchedVersion
+	}
+	if err := kzg4844.VerifyProof(commitment, point, claim, proof); err != nil {
+		log.Trace("POINTEVALUATION failed", "err", err)
+		return nil, errBlobVerifyKZGProof
+	}
+	return common.CopyBytes(blobPrecompileReturnValue), nil
+}
+
+var (
+	errBLS12381InvalidInputLength = errors.New("invalid input length")
+	errBLS12381G1PointSubgroup    = errors.New("g1 point is not on correct subgroup")
+	errBLS12381G2PointSubgroup    = errors.New("g2 point is not on correct subgroup")
+	errBLS12381FieldElementTop    = errors.New("invalid field element top bytes")
+)
+
+// decodeBLS12381G1 decodes a 128 byte padded G1 point, making sure it is on the
+// curve and in the correct subgroup.
+func decodeBLS12381G1(g *bls12381.G1, in []byte) (*bls12381.PointG1, error) {
+	p, err := g.DecodePoint(in)
+	if err != nil {
+		return nil, err
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errBLS12381G1PointSubgroup
+	}
+	return p, nil
+}
+
+// decodeBLS12381G2 decodes a 256 byte padded G2 point, making sure it is on the
+// curve and in the correct subgroup.
+func decodeBLS12381G2(g *bls12381.G2, in []byte) (*bls12381.PointG2, error) {
+	p, err := g.DecodePoint(in)
+	if err != nil {
+		return nil, err
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errBLS12381G2PointSubgroup
+	}
+	return p, nil
+}
+
+// decodeBLS12381FieldElement strips the 16 zero bytes of padding off a 64 byte
+// field element. Whether the remainder is less than the field modulus is left
+// to the curve mapping, which rejects non-canonical elements.
+func decodeBLS12381FieldElement(in []byte) ([]byte, error) {
+	if !allZero(in[:16]) {
+		return nil, errBLS12381FieldElementTop
+	}
+	return in[16:64], nil
+}
+
+// bls12381G1Add implements the EIP-2537 G1Add precompile.
+type bls12381G1Add struct{}
+
+func (c *bls12381G1Add) Name() string { return "bls12381G1Add" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G1AddGas
+}
+
+func (c *bls12381G1Add) Run(in []byte) ([]byte, error) {
+	// "in" is (p0, p1), two padded G1 points of 128 bytes each
+	if len(in) != 256 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	g := bls12381.NewG1()
+
+	p0, err := decodeBLS12381G1(g, in[:128])
+	if err != nil {
+		return nil, err
+	}
+	p1, err := decodeBLS12381G1(g, in[128:])
+	if err != nil {
+		return nil, err
+	}
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	return g.EncodePoint(r), nil
+}
+
+// bls12381MultiExpDiscount returns the EIP-2537 multi exponentiation discount
+// for k point-scalar pairs.
+func bls12381MultiExpDiscount(k int) uint64 {
+	if dLen := len(params.Bls12381MultiExpDiscountTable); k > dLen {
+		return params.Bls12381MultiExpDiscountTable[dLen-1]
+	}
+	return params.Bls12381MultiExpDiscountTable[k-1]
+}
+
+// bls12381G1MultiExp implements the EIP-2537 G1MultiExp precompile.
+type bls12381G1MultiExp struct{}
+
+func (c *bls12381G1MultiExp) Name() string { return "bls12381G1MultiExp" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract,
+// which is the discounted price of k G1 scalar multiplications.
+func (c *bls12381G1MultiExp) RequiredGas(input []byte) uint64 {
+	// Malformed inputs can't be priced, charge everything so the call can never
+	// be made for free and fails before running
+	k := len(input) / 160
+	if k == 0 || len(input)%160 != 0 {
+		return math.MaxUint64
+	}
+	return uint64(k) * params.Bls12381G1MulGas * bls12381MultiExpDiscount(k) / params.Bls12381MultiExpDiscountMultiplier
+}
+
+func (c *bls12381G1MultiExp) Run(in []byte) ([]byte, error) {
+	// "in" is k times (point, scalar), a padded G1 point of 128 bytes and a 32 byte scalar
+	k := len(in) / 160
+	if k == 0 || len(in)%160 != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	var (
+		g       = bls12381.NewG1()
+		points  = make([]*bls12381.PointG1, k)
+		scalars = make([]*big.Int, k)
+		err     error
+	)
+	for i := 0; i < k; i++ {
+		off := 160 * i
+		if points[i], err = decodeBLS12381G1(g, in[off:off+128]); err != nil {
+			return nil, err
+		}
+		scalars[i] = new(big.Int).SetBytes(in[off+128 : off+160])
+	}
+	r := g.New()
+	if _, err := g.MultiExp(r, points, scalars); err != nil {
+		return nil, err
+	}
+	return g.EncodePoint(r), nil
+}
+
+// bls12381G2Add implements the EIP-2537 G2Add precompile.
+type bls12381G2Add struct{}
+
+func (c *bls12381G2Add) Name() string { return "bls12381G2Add" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2AddGas
+}
+
+func (c *bls12381G2Add) Run(in []byte) ([]byte, error) {
+	// "in" is (p0, p1), two padded G2 points of 256 bytes each
+	if len(in) != 512 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	g := bls12381.NewG2()
+
+	p0, err := decodeBLS12381G2(g, in[:256])
+	if err != nil {
+		return nil, err
+	}
+	p1, err := decodeBLS12381G2(g, in[256:])
+	if err != nil {
+		return nil, err
+	}
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	return g.EncodePoint(r), nil
+}
+
+// bls12381G2Mul implements the EIP-2537 G2Mul precompile.
+type bls12381G2Mul struct{}
+
+func (c *bls12381G2Mul) Name() string { return "bls12381G2Mul" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2MulGas
+}
+
+func (c *bls12381G2Mul) Run(in []byte) ([]byte, error) {
+	// "in" is (p, e), a padded G2 point of 256 bytes and a 32 byte scalar
+	if len(in) != 288 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	g := bls12381.NewG2()
+
+	p, err := decodeBLS12381G2(g, in[:256])
+	if err != nil {
+		return nil, err
+	}
+	e := new(big.Int).SetBytes(in[256:])
+
+	r := g.New()
+	g.MulScalar(r, p, e)
+
+	return g.EncodePoint(r), nil
+}
+
+// bls12381G2MultiExp implements the EIP-2537 G2MultiExp precompile.
+type bls12381G2MultiExp struct{}
+
+func (c *bls12381G2MultiExp) Name() string { return "bls12381G2MultiExp" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract,
+// which is the discounted price of k G2 scalar multiplications.
+func (c *bls12381G2MultiExp) RequiredGas(input []byte) uint64 {
+	// Malformed inputs can't be priced, charge everything so the call can never
+	// be made for free and fails before running
+	k := len(input) / 288
+	if k == 0 || len(input)%288 != 0 {
+		return math.MaxUint64
+	}
+	return uint64(k) * params.Bls12381G2MulGas * bls12381MultiExpDiscount(k) / params.Bls12381MultiExpDiscountMultiplier
+}
+
+func (c *bls12381G2MultiExp) Run(in []byte) ([]byte, error) {
+	// "in" is k times (point, scalar), a padded G2 point of 256 bytes and a 32 byte scalar
+	k := len(in) / 288
+	if k == 0 || len(in)%288 != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	var (
+		g       = bls12381.NewG2()
+		points  = make([]*bls12381.PointG2, k)
+		scalars = make([]*big.Int, k)
+		err     error
+	)
+	for i := 0; i < k; i++ {
+		off := 288 * i
+		if points[i], err = decodeBLS12381G2(g, in[off:off+256]); err != nil {
+			return nil, err
+		}
+		scalars[i] = new(big.Int).SetBytes(in[off+256 : off+288])
+	}
+	r := g.New()
+	if _, err := g.MultiExp(r, points, scalars); err != nil {
+		return nil, err
+	}
+	return g.EncodePoint(r), nil
+}
+
+// bls12381Pairing implements the EIP-2537 Pairing precompile.
+type bls12381Pairing struct{}
+
+func (c *bls12381Pairing) Name() string { return "bls12381Pairing" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12381PairingBaseGas + uint64(len(input)/384)*params.Bls12381PairingPerPairGas
+}
+
+func (c *bls12381Pairing) Run(in []byte) ([]byte, error) {
+	// "in" is k times (g1, g2), a padded G1 point of 128 bytes and a padded G2
+	// point of 256 bytes. An empty input is the product of zero pairings, which
+	// is the identity.
+	if len(in)%384 != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	e := bls12381.NewPairingEngine()
+
+	for i := 0; i < len(in)/384; i++ {
+		off := 384 * i
+
+		p1, err := decodeBLS12381G1(e.G1, in[off:off+128])
+		if err != nil {
+			return nil, err
+		}
+		p2, err := decodeBLS12381G2(e.G2, in[off+128:off+384])
+		if err != nil {
+			return nil, err
+		}
+		e.AddPair(p1, p2)
+	}
+	if e.Check() {
+		return true32Byte, nil
+	}
+	return false32Byte, nil
+}
+
+// bls12381MapG1 implements the EIP-2537 MapG1 precompile.
+type bls12381MapG1 struct{}
+
+func (c *bls12381MapG1) Name() string { return "bls12381MapG1" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapG1) RequiredGas(input []byte) uint64 {
+	return params.Bls12381MapG1Gas
+}
+
+func (c *bls12381MapG1) Run(in []byte) ([]byte, error) {
+	// "in" is a single padded base field element of 64 bytes
+	if len(in) != 64 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	u, err := decodeBLS12381FieldElement(in)
+	if err != nil {
+		return nil, err
+	}
+	g := bls12381.NewG1()
+
+	r, err := g.MapToCurve(u)
+	if err != nil {
+		return nil, err
+	}
+	return g.EncodePoint(r), nil
+}
+
+// bls12381MapG2 implements the EIP-2537 MapG2 precompile.
+type bls12381MapG2 struct{}
+
+func (c *bls12381MapG2) Name() string { return "bls12381MapG2" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapG2) RequiredGas(input []byte) uint64 {
+	return params.Bls12381MapG2Gas
 }
-func (c *dataCopy) Run(in []byte) []byte {
-	return in
+
+func (c *bls12381MapG2) Run(in []byte) ([]byte, error) {
+	// "in" is (c0, c1), the two padded 64 byte base field coefficients of an
+	// element of the quadratic extension
+	if len(in) != 128 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	c0, err := decodeBLS12381FieldElement(in[:64])
+	if err != nil {
+		return nil, err
+	}
+	c1, err := decodeBLS12381FieldElement(in[64:])
+	if err != nil {
+		return nil, err
+	}
+	// The curve library expects the coefficients in (c1, c0) order
+	u := make([]byte, 96)
+	copy(u[:48], c1)
+	copy(u[48:], c0)
+
+	g := bls12381.NewG2()
+
+	r, err := g.MapToCurve(u)
+	if err != nil {
+		return nil, err
+	}
+	return g.EncodePoint(r), nil
+}
+
+// p256Verify implements the secp256r1 (NIST P-256) signature verification
+// of RIP-7212 as a native contract.
+type p256Verify struct{}
+
+func (c *p256Verify) Name() string { return "p256Verify" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *p256Verify) RequiredGas(input []byte) uint64 {
+	return params.P256VerifyGas
+}
+
+func (c *p256Verify) Run(in []byte) ([]byte, error) {
+	const p256VerifyInputLength = 160
+
+	// "in" is (hash, r, s, x, y), each 32 bytes. Any failure, including a
+	// malformed input, results in an empty output rather than an error.
+	if len(in) != p256VerifyInputLength {
+		return nil, nil
+	}
+	var (
+		curve = elliptic.P256()
+		hash  = in[0:32]
+		r     = new(big.Int).SetBytes(in[32:64])
+		s     = new(big.Int).SetBytes(in[64:96])
+		x     = new(big.Int).SetBytes(in[96:128])
+		y     = new(big.Int).SetBytes(in[128:160])
+	)
+	// Make sure the signature values are within [1, n-1]
+	n := curve.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		log.Trace("P256VERIFY error: r or s value invalid")
+		return nil, nil
+	}
+	// Make sure the public key is a canonical point on the curve
+	p := curve.Params().P
+	if x.Cmp(p) >= 0 || y.Cmp(p) >= 0 || !curve.IsOnCurve(x, y) {
+		log.Trace("P256VERIFY error: public key invalid")
+		return nil, nil
+	}
+	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash, r, s) {
+		return nil, nil
+	}
+	return true32Byte, nil
 }
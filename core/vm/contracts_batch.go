@@ -0,0 +1,79 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+)
+
+// parallelize calls fn for every index in [0, n) from up to GOMAXPROCS
+// goroutines, returning once all calls finished.
+func parallelize(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	var (
+		pend sync.WaitGroup
+		next = int64(-1)
+	)
+	for w := 0; w < workers; w++ {
+		pend.Add(1)
+		go func() {
+			defer pend.Done()
+			for i := int(atomic.AddInt64(&next, 1)); i < n; i = int(atomic.AddInt64(&next, 1)) {
+				fn(i)
+			}
+		}()
+	}
+	pend.Wait()
+}
+
+// EcrecoverBatch recovers the signers of a batch of ecrecover inputs
+// concurrently, returning the outputs in input order. Every output is exactly
+// what the ecrecover precompile returns for the same input. It is meant for
+// off-chain signature checking, e.g. in light clients, and is not used by the
+// EVM.
+func EcrecoverBatch(inputs [][]byte) [][]byte {
+	var (
+		p       = &ecrecover{}
+		outputs = make([][]byte, len(inputs))
+	)
+	parallelize(len(inputs), func(i int) {
+		outputs[i], _ = p.Run(inputs[i])
+	})
+	return outputs
+}
+
+// Bn256PairingCheckParallel runs a bn256 pairing check precompile input,
+// computing the Miller loops of the pairs concurrently. Its output is exactly
+// that of the precompile, but it is meant for tooling such as gas estimation
+// and is never used by the EVM itself.
+func Bn256PairingCheckParallel(in []byte) ([]byte, error) {
+	cs, ts, err := decodeBn256Pairs(in)
+	if err != nil {
+		return nil, err
+	}
+	if bn256.PairingCheckParallel(cs, ts) {
+		return true32Byte, nil
+	}
+	return false32Byte, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/golang-lru"
+)
+
+// ecrecoverCache holds the *lru.Cache of recovered addresses keyed by the 128
+// byte ecrecover input, or nil if caching is disabled.
+var ecrecoverCache atomic.Value
+
+// SetEcrecoverCacheSize enables caching the results of the last size distinct
+// ecrecover inputs, which helps when the same signatures get validated over and
+// over, e.g. in the transaction pool. A non-positive size disables the cache,
+// which is the default. Caching has no effect on the gas charged.
+func SetEcrecoverCacheSize(size int) {
+	if size <= 0 {
+		ecrecoverCache.Store((*lru.Cache)(nil))
+		return
+	}
+	cache, _ := lru.New(size)
+	ecrecoverCache.Store(cache)
+}
+
+// cachedEcrecover returns the recovered address for the padded 128 byte input,
+// consulting and populating the cache if enabled.
+func cachedEcrecover(in []byte) []byte {
+	cache, _ := ecrecoverCache.Load().(*lru.Cache)
+	if cache == nil {
+		return ecrecoverAddress(in)
+	}
+	var key [128]byte
+	copy(key[:], in)
+
+	addr, ok := cache.Get(key)
+	if !ok {
+		addr = ecrecoverAddress(in)
+		cache.Add(key, addr)
+	}
+	// Failed recoveries are cached as nil and must be returned as such
+	if addr := addr.([]byte); addr != nil {
+		return common.CopyBytes(addr)
+	}
+	return nil
+}
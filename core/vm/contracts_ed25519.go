@@ -0,0 +1,56 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ed25519Verify implements the ed25519 signature verification as a native
+// contract.
+type ed25519Verify struct{}
+
+func (c *ed25519Verify) Name() string { return "ed25519Verify" }
+
+// RequiredGas returns the gas required to execute the pre-compiled contract,
+// which is a base price plus a price per 32 byte word of the message.
+func (c *ed25519Verify) RequiredGas(input []byte) uint64 {
+	const ed25519VerifyHeaderLength = 128
+
+	var msgLen uint64
+	if len(input) > ed25519VerifyHeaderLength {
+		msgLen = uint64(len(input) - ed25519VerifyHeaderLength)
+	}
+	return (msgLen+31)/32*params.Ed25519VerifyWordGas + params.Ed25519VerifyGas
+}
+
+func (c *ed25519Verify) Run(in []byte) ([]byte, error) {
+	// "in" is (length, pubkey, sig, msg), 32, 32, 64 and length bytes
+	if len(in) < 128 {
+		return false32Byte, nil
+	}
+	if !allZero(in[:24]) || binary.BigEndian.Uint64(in[24:32]) != uint64(len(in)-128) {
+		return false32Byte, nil
+	}
+	if !ed25519.Verify(ed25519.PublicKey(in[32:64]), in[128:], in[64:128]) {
+		return false32Byte, nil
+	}
+	return true32Byte, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ed25519VerifyTests are the test data for the ed25519 signature verification
+// precompiled contract, taken from RFC 8032 section 7.1.
+var ed25519VerifyTests = []precompiledTest{
+	{
+		input: "0000000000000000000000000000000000000000000000000000000000000000" +
+			"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a" +
+			"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e06522490155" +
+			"5fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		gas:      2000,
+		name:     "rfc8032_test1",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c" +
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+			"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00" +
+			"72",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		gas:      2012,
+		name:     "rfc8032_test2",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000002" +
+			"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025" +
+			"6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac" +
+			"18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a" +
+			"af82",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		gas:      2012,
+		name:     "rfc8032_test3",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000002" +
+			"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025" +
+			"6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac" +
+			"18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a" +
+			"af83",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "tampered_message",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c" +
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+			"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c01" +
+			"72",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "tampered_signature",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000002" +
+			"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c" +
+			"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+			"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00" +
+			"72",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "length_mismatch",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "short_input",
+	},
+}
+
+// Tests the RFC 8032 sample inputs of the ed25519 signature verification.
+func TestPrecompiledEd25519Verify(t *testing.T) {
+	for _, test := range ed25519VerifyTests {
+		testPrecompiled("0101", test, t)
+	}
+}
+
+// Tests that the ed25519 set deploys the verifier next to the Berlin contracts.
+func TestPrecompiledContractsEd25519(t *testing.T) {
+	p, ok := PrecompiledContractsEd25519[common.BytesToAddress([]byte{1, 0x01})]
+	if !ok {
+		t.Fatalf("ed25519 verifier missing")
+	}
+	if _, ok := p.(*ed25519Verify); !ok {
+		t.Errorf("contract type mismatch: have %T, want %T", p, &ed25519Verify{})
+	}
+	for addr := range PrecompiledContractsBerlin {
+		if _, ok := PrecompiledContractsEd25519[addr]; !ok {
+			t.Errorf("berlin contract %x missing", addr)
+		}
+	}
+}
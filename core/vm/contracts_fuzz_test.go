@@ -0,0 +1,109 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// +build go1.18
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// fuzzMaxInputLen is the longest fuzzed input which is still executed. Apart
+	// from the BLAKE2 round count, the work of every contract is bounded by the
+	// length of its input, including modexp which refuses unpayable lengths.
+	fuzzMaxInputLen = 4096
+
+	// fuzzMaxBlake2FRounds is the highest BLAKE2 round count which is executed,
+	// keeping a single input from stalling the fuzzer for minutes.
+	fuzzMaxBlake2FRounds = 1 << 16
+)
+
+// precompileOutputSize returns the documented output size of a successful call
+// to the given contract, or -1 if the contract may also return an empty output
+// (signature verifiers signalling failure).
+func precompileOutputSize(p PrecompiledContract, input []byte) int {
+	switch p.(type) {
+	case *ecrecover, *p256Verify:
+		return -1
+	case *sha256hash, *ripemd160hash, *bn256Pairing, *bls12381Pairing, *ed25519Verify:
+		return 32
+	case *dataCopy:
+		return len(input)
+	case *bigModExp, *bigModexpEIP2565:
+		return int(new(big.Int).SetBytes(getData(input, big64, common.Big32)).Uint64())
+	case *bn256Add, *bn256ScalarMul, *blake2F, *kzgPointEvaluation:
+		return 64
+	case *bls12381G1Add, *bls12381G1MultiExp, *bls12381MapG1:
+		return 128
+	case *bls12381G2Add, *bls12381G2Mul, *bls12381G2MultiExp, *bls12381MapG2:
+		return 256
+	}
+	panic(fmt.Sprintf("unknown output size of %T", p))
+}
+
+// FuzzPrecompiles feeds random inputs to every builtin precompiled contract,
+// checking that neither pricing nor execution panics, regardless of the price,
+// and that successful calls produce outputs of the documented size.
+func FuzzPrecompiles(f *testing.F) {
+	addrs := make(addressesByValue, 0, len(allPrecompiles))
+	for addr := range allPrecompiles {
+		addrs = append(addrs, addr)
+	}
+	sort.Sort(addrs)
+
+	// Seed the corpus with boundary inputs for every contract
+	seeds := [][]byte{nil, ecrecoverCacheInput, bytes.Repeat([]byte{0xff}, 128)}
+	for _, size := range []int{1, 31, 32, 33, 64, 96, 128, 160, 192, 213, 256, 384, 768} {
+		seeds = append(seeds, make([]byte, size))
+	}
+	for i := range addrs {
+		for _, seed := range seeds {
+			f.Add(uint8(i), seed)
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, input []byte) {
+		p := allPrecompiles[addrs[int(index)%len(addrs)]]
+
+		if len(input) > fuzzMaxInputLen {
+			return
+		}
+		p.RequiredGas(input)
+
+		if _, ok := p.(*blake2F); ok && len(input) >= 4 && binary.BigEndian.Uint32(input) > fuzzMaxBlake2FRounds {
+			input = common.CopyBytes(input)
+			binary.BigEndian.PutUint32(input, fuzzMaxBlake2FRounds)
+		}
+		output, err := p.Run(input)
+		if err != nil {
+			return
+		}
+		switch size := precompileOutputSize(p, input); {
+		case size < 0 && len(output) != 0 && len(output) != 32:
+			t.Errorf("%T: output length mismatch: have %d, want 0 or 32", p, len(output))
+		case size >= 0 && len(output) != size:
+			t.Errorf("%T: output length mismatch: have %d, want %d", p, len(output), size)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Stat contains the usage statistics of a single precompiled contract.
+type Stat struct {
+	Calls uint64 // Number of times the contract was run
+	Gas   uint64 // Cumulative gas charged for the runs
+}
+
+// PrecompileMetrics collects per address usage statistics of the precompiled
+// contracts. Collection is disabled by default and may be toggled at any time,
+// including while EVMs are running.
+var PrecompileMetrics = &precompileMetrics{
+	stats: make(map[common.Address]*Stat),
+}
+
+type precompileMetrics struct {
+	enabled int32 // Whether to collect statistics at all (accessed atomically)
+
+	lock  sync.Mutex
+	stats map[common.Address]*Stat
+}
+
+// Enabled reports whether statistics are being collected.
+func (m *precompileMetrics) Enabled() bool {
+	return atomic.LoadInt32(&m.enabled) == 1
+}
+
+// SetEnabled starts or stops collecting statistics.
+func (m *precompileMetrics) SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&m.enabled, 1)
+	} else {
+		atomic.StoreInt32(&m.enabled, 0)
+	}
+}
+
+// record accounts a single run of the precompiled contract at addr charging
+// the given amount of gas.
+func (m *precompileMetrics) record(addr common.Address, gas uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	stat, ok := m.stats[addr]
+	if !ok {
+		stat = new(Stat)
+		m.stats[addr] = stat
+	}
+	stat.Calls++
+	stat.Gas += gas
+}
+
+// Reset drops all the statistics collected so far.
+func (m *precompileMetrics) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.stats = make(map[common.Address]*Stat)
+}
+
+// PrecompileStats returns a snapshot of the usage statistics collected for the
+// precompiled contracts, keyed by contract address.
+func PrecompileStats() map[common.Address]Stat {
+	PrecompileMetrics.lock.Lock()
+	defer PrecompileMetrics.lock.Unlock()
+
+	stats := make(map[common.Address]Stat, len(PrecompileMetrics.stats))
+	for addr, stat := range PrecompileMetrics.stats {
+		stats[addr] = *stat
+	}
+	return stats
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// runPrecompileAt runs the builtin precompiled contract at addr with the given
+// input, returning the gas it was charged.
+func runPrecompileAt(t *testing.T, addr common.Address, input []byte) uint64 {
+	p := PrecompiledContracts[addr]
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), p.RequiredGas(input))
+	contract.CodeAddr = &addr
+
+	if _, _, err := RunPrecompiledContract(p, input, contract, PrecompileContext{}); err != nil {
+		t.Fatalf("failed to run precompile %x: %v", addr, err)
+	}
+	return p.RequiredGas(input)
+}
+
+// Tests that the precompile metrics count runs and gas per address, and that
+// nothing is collected while disabled.
+func TestPrecompileMetrics(t *testing.T) {
+	defer func(enabled bool) {
+		PrecompileMetrics.SetEnabled(enabled)
+		PrecompileMetrics.Reset()
+	}(PrecompileMetrics.Enabled())
+
+	var (
+		sha256Addr   = common.BytesToAddress([]byte{2})
+		identityAddr = common.BytesToAddress([]byte{4})
+	)
+	PrecompileMetrics.Reset()
+	PrecompileMetrics.SetEnabled(false)
+	runPrecompileAt(t, sha256Addr, []byte{1})
+	if stats := PrecompileStats(); len(stats) != 0 {
+		t.Fatalf("collected stats while disabled: %v", stats)
+	}
+
+	PrecompileMetrics.SetEnabled(true)
+	var sha256Gas, identityGas uint64
+	for i := 0; i < 3; i++ {
+		sha256Gas += runPrecompileAt(t, sha256Addr, make([]byte, 32*i))
+	}
+	for i := 0; i < 5; i++ {
+		identityGas += runPrecompileAt(t, identityAddr, make([]byte, 64))
+	}
+	stats := PrecompileStats()
+	if have, want := stats[sha256Addr], (Stat{Calls: 3, Gas: sha256Gas}); have != want {
+		t.Errorf("sha256 stats mismatch: have %+v, want %+v", have, want)
+	}
+	if have, want := stats[identityAddr], (Stat{Calls: 5, Gas: identityGas}); have != want {
+		t.Errorf("identity stats mismatch: have %+v, want %+v", have, want)
+	}
+	if len(stats) != 2 {
+		t.Errorf("stats count mismatch: have %d, want 2", len(stats))
+	}
+	// The returned snapshot must not alias the live counters
+	stats[sha256Addr] = Stat{}
+	if PrecompileStats()[sha256Addr].Calls != 3 {
+		t.Errorf("stats snapshot aliases the live counters")
+	}
+}
+
+// Tests that collection can be toggled while contracts are being run.
+func TestPrecompileMetricsToggleConcurrent(t *testing.T) {
+	defer func(enabled bool) {
+		PrecompileMetrics.SetEnabled(enabled)
+		PrecompileMetrics.Reset()
+	}(PrecompileMetrics.Enabled())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			PrecompileMetrics.SetEnabled(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		runPrecompileAt(t, common.BytesToAddress([]byte{4}), []byte{1})
+	}
+	<-done
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	errPrecompileBuiltin    = errors.New("address occupied by a builtin precompiled contract")
+	errPrecompileRegistered = errors.New("address occupied by a registered precompiled contract")
+	errPrecompileNil        = errors.New("nil precompiled contract")
+	errPrecompileMissing    = errors.New("no precompiled contract at address")
+)
+
+var (
+	customPrecompilesLock sync.Mutex   // Serialises writers of the custom precompile set
+	customPrecompiles     atomic.Value // Copy-on-write map[common.Address]PrecompiledContract
+)
+
+func init() {
+	customPrecompiles.Store(make(map[common.Address]PrecompiledContract))
+}
+
+// RegisterPrecompile registers a custom native contract at the given address.
+// Registration fails if the address is already taken by a builtin or another
+// registered contract. It is safe to call concurrently with lookups.
+func RegisterPrecompile(addr common.Address, p PrecompiledContract) error {
+	if p == nil {
+		return errPrecompileNil
+	}
+	if isBuiltinPrecompile(addr) {
+		return errPrecompileBuiltin
+	}
+	customPrecompilesLock.Lock()
+	defer customPrecompilesLock.Unlock()
+
+	current := customPrecompiles.Load().(map[common.Address]PrecompiledContract)
+	if _, ok := current[addr]; ok {
+		return errPrecompileRegistered
+	}
+	updated := make(map[common.Address]PrecompiledContract, len(current)+1)
+	for a, c := range current {
+		updated[a] = c
+	}
+	updated[addr] = p
+	customPrecompiles.Store(updated)
+	return nil
+}
+
+// UnregisterPrecompile removes a custom native contract registered at the given
+// address. Builtin contracts are not affected.
+func UnregisterPrecompile(addr common.Address) {
+	customPrecompilesLock.Lock()
+	defer customPrecompilesLock.Unlock()
+
+	current := customPrecompiles.Load().(map[common.Address]PrecompiledContract)
+	if _, ok := current[addr]; !ok {
+		return
+	}
+	updated := make(map[common.Address]PrecompiledContract, len(current))
+	for a, c := range current {
+		if a != addr {
+			updated[a] = c
+		}
+	}
+	customPrecompiles.Store(updated)
+}
+
+// Precompile returns the native contract at the given address active under the
+// given chain rules, looking at the fork specific set of builtin contracts first
+// and the registered ones second. Registered contracts are active under all rules.
+func Precompile(addr common.Address, rules params.Rules) (PrecompiledContract, bool) {
+	return lookupPrecompile(PrecompiledContractsForConfig(rules), addr)
+}
+
+// lookupPrecompile returns the native contract at the given address, looking at
+// the given set of builtin contracts first and the registered ones second.
+func lookupPrecompile(builtins map[common.Address]PrecompiledContract, addr common.Address) (PrecompiledContract, bool) {
+	if p := builtins[addr]; p != nil {
+		return p, true
+	}
+	p, ok := customPrecompiles.Load().(map[common.Address]PrecompiledContract)[addr]
+	return p, ok
+}
+
+// EstimatePrecompileGas returns the gas a call to the native contract at the
+// given address would be charged under the given chain rules, without running
+// the contract.
+func EstimatePrecompileGas(addr common.Address, input []byte, rules params.Rules) (uint64, error) {
+	p, ok := Precompile(addr, rules)
+	if !ok {
+		return 0, errPrecompileMissing
+	}
+	return p.RequiredGas(input), nil
+}
+
+// isBuiltinPrecompile reports whether the address is taken by a builtin contract
+// in any of the fork specific sets.
+func isBuiltinPrecompile(addr common.Address) bool {
+	return builtinPrecompile(addr) != nil
+}
+
+// builtinPrecompile returns the builtin contract at the given address from any
+// of the fork specific sets, or nil if there is none.
+func builtinPrecompile(addr common.Address) PrecompiledContract {
+	sets := []map[common.Address]PrecompiledContract{
+		PrecompiledContracts,
+		PrecompiledContractsHomestead,
+		PrecompiledContractsByzantium,
+		PrecompiledContractsIstanbul,
+		PrecompiledContractsBerlin,
+		PrecompiledContractsCancun,
+		PrecompiledContractsPrague,
+		PrecompiledContractsRIP7212,
+		PrecompiledContractsEd25519,
+	}
+	for _, set := range sets {
+		if p, ok := set[addr]; ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// PrecompileName returns the name of the builtin or registered native contract
+// at the given address, falling back to the hex address for unnamed contracts
+// and addresses without any.
+func PrecompileName(addr common.Address) string {
+	p := builtinPrecompile(addr)
+	if p == nil {
+		p = customPrecompiles.Load().(map[common.Address]PrecompiledContract)[addr]
+	}
+	return precompileName(p, addr.Hex())
+}
+
+// precompileName returns the name of the given native contract, or the fallback
+// if the contract is unnamed.
+func precompileName(p PrecompiledContract, fallback string) string {
+	if named, ok := p.(Named); ok {
+		return named.Name()
+	}
+	return fallback
+}
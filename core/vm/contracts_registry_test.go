@@ -0,0 +1,305 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// echoPrecompile is a trivial native contract returning its input, charging
+// a base price plus one unit of gas per input byte.
+type echoPrecompile struct{}
+
+func (c *echoPrecompile) RequiredGas(input []byte) uint64 {
+	return 100 + uint64(len(input))
+}
+
+func (c *echoPrecompile) Run(in []byte) ([]byte, error) {
+	return common.CopyBytes(in), nil
+}
+
+// Tests that a registered contract is dispatched to by the interpreter and
+// charged for accordingly.
+func TestRegisterPrecompile(t *testing.T) {
+	addr := common.HexToAddress("0xff01")
+	if err := RegisterPrecompile(addr, &echoPrecompile{}); err != nil {
+		t.Fatalf("failed to register precompile: %v", err)
+	}
+	defer UnregisterPrecompile(addr)
+
+	if _, ok := Precompile(addr, params.Rules{}); !ok {
+		t.Fatalf("registered precompile not found")
+	}
+	var (
+		env      = NewEVM(Context{}, nil, params.TestChainConfig, Config{})
+		ref      = AccountRef(common.HexToAddress("1337"))
+		input    = []byte("hello precompile")
+		contract = NewContract(ref, AccountRef(addr), new(big.Int), 1000)
+	)
+	contract.CodeAddr = &addr
+
+	ret, err := env.Interpreter().Run(contract, input)
+	if err != nil {
+		t.Fatalf("failed to run registered precompile: %v", err)
+	}
+	if !bytes.Equal(ret, input) {
+		t.Errorf("output mismatch: have %x, want %x", ret, input)
+	}
+	if want := uint64(1000 - 100 - len(input)); contract.Gas != want {
+		t.Errorf("gas mismatch: have %d, want %d", contract.Gas, want)
+	}
+	// Running out of gas should fail the same way as for builtins
+	contract = NewContract(ref, AccountRef(addr), new(big.Int), 50)
+	contract.CodeAddr = &addr
+	if _, err := env.Interpreter().Run(contract, input); err != ErrOutOfGas {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrOutOfGas)
+	}
+}
+
+// Tests that registration is rejected for occupied addresses and that
+// unregistering makes the address available again.
+func TestRegisterPrecompileConflicts(t *testing.T) {
+	if err := RegisterPrecompile(common.BytesToAddress([]byte{1}), &echoPrecompile{}); err != errPrecompileBuiltin {
+		t.Errorf("builtin ecrecover: error mismatch: have %v, want %v", err, errPrecompileBuiltin)
+	}
+	if err := RegisterPrecompile(common.BytesToAddress([]byte{9}), &echoPrecompile{}); err != errPrecompileBuiltin {
+		t.Errorf("builtin blake2F: error mismatch: have %v, want %v", err, errPrecompileBuiltin)
+	}
+	addr := common.HexToAddress("0xff02")
+	if err := RegisterPrecompile(addr, nil); err != errPrecompileNil {
+		t.Errorf("nil contract: error mismatch: have %v, want %v", err, errPrecompileNil)
+	}
+	if err := RegisterPrecompile(addr, &echoPrecompile{}); err != nil {
+		t.Fatalf("failed to register precompile: %v", err)
+	}
+	if err := RegisterPrecompile(addr, &echoPrecompile{}); err != errPrecompileRegistered {
+		t.Errorf("duplicate: error mismatch: have %v, want %v", err, errPrecompileRegistered)
+	}
+	UnregisterPrecompile(addr)
+	if _, ok := Precompile(addr, params.Rules{}); ok {
+		t.Errorf("unregistered precompile still found")
+	}
+	if err := RegisterPrecompile(addr, &echoPrecompile{}); err != nil {
+		t.Errorf("failed to re-register precompile: %v", err)
+	}
+	UnregisterPrecompile(addr)
+
+	// Unregistering a builtin must be a noop
+	UnregisterPrecompile(common.BytesToAddress([]byte{1}))
+	if _, ok := Precompile(common.BytesToAddress([]byte{1}), params.Rules{}); !ok {
+		t.Errorf("builtin precompile removed")
+	}
+}
+
+// blockPrecompile is a native contract returning the number of the block it
+// is executed in, read from its execution context.
+type blockPrecompile struct{}
+
+func (c *blockPrecompile) RequiredGas(input []byte) uint64 {
+	return 100
+}
+
+func (c *blockPrecompile) Run(in []byte) ([]byte, error) {
+	return nil, errors.New("run without context")
+}
+
+func (c *blockPrecompile) RunWithContext(in []byte, ctx PrecompileContext) ([]byte, error) {
+	return common.LeftPadBytes(ctx.BlockNumber.Bytes(), 32), nil
+}
+
+// Tests that contracts requiring the execution context are run with the one
+// of the executing EVM.
+func TestContextualPrecompile(t *testing.T) {
+	addr := common.HexToAddress("0xff03")
+	if err := RegisterPrecompile(addr, &blockPrecompile{}); err != nil {
+		t.Fatalf("failed to register precompile: %v", err)
+	}
+	defer UnregisterPrecompile(addr)
+
+	var (
+		env      = NewEVM(Context{BlockNumber: big.NewInt(1234), Time: big.NewInt(5678)}, nil, params.TestChainConfig, Config{})
+		contract = NewContract(AccountRef(common.HexToAddress("1337")), AccountRef(addr), new(big.Int), 1000)
+	)
+	contract.CodeAddr = &addr
+
+	ret, err := env.Interpreter().Run(contract, nil)
+	if err != nil {
+		t.Fatalf("failed to run contextual precompile: %v", err)
+	}
+	if want := common.LeftPadBytes(big.NewInt(1234).Bytes(), 32); !bytes.Equal(ret, want) {
+		t.Errorf("output mismatch: have %x, want %x", ret, want)
+	}
+}
+
+// panickingPrecompile is a native contract blowing up if ever run.
+type panickingPrecompile struct{ echoPrecompile }
+
+func (c *panickingPrecompile) Run(in []byte) ([]byte, error) {
+	panic("precompile run during gas estimation")
+}
+
+// Tests that precompile gas estimation prices calls per the active fork without
+// running the contracts.
+func TestEstimatePrecompileGas(t *testing.T) {
+	addr := common.HexToAddress("0xff05")
+	if err := RegisterPrecompile(addr, &panickingPrecompile{}); err != nil {
+		t.Fatalf("failed to register precompile: %v", err)
+	}
+	defer UnregisterPrecompile(addr)
+
+	var (
+		frontier = params.Rules{}
+		istanbul = params.Rules{IsByzantium: true, IsIstanbul: true}
+	)
+	tests := []struct {
+		addr  common.Address
+		input []byte
+		rules params.Rules
+		gas   uint64
+		err   error
+	}{
+		{common.BytesToAddress([]byte{1}), nil, frontier, params.EcrecoverGas, nil},
+		{common.BytesToAddress([]byte{1}), make([]byte, 1024), frontier, params.EcrecoverGas, nil},
+		{common.BytesToAddress([]byte{2}), nil, frontier, params.Sha256Gas, nil},
+		{common.BytesToAddress([]byte{2}), make([]byte, 32), frontier, params.Sha256Gas + params.Sha256WordGas, nil},
+		{common.BytesToAddress([]byte{2}), make([]byte, 33), frontier, params.Sha256Gas + 2*params.Sha256WordGas, nil},
+		{common.BytesToAddress([]byte{9}), make([]byte, 213), frontier, 0, errPrecompileMissing},
+		{common.BytesToAddress([]byte{9}), make([]byte, 213), istanbul, 0, nil},
+		{addr, []byte{1, 2, 3}, frontier, 103, nil},
+		{common.HexToAddress("0xff06"), nil, istanbul, 0, errPrecompileMissing},
+	}
+	for i, test := range tests {
+		gas, err := EstimatePrecompileGas(test.addr, test.input, test.rules)
+		if err != test.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+		if gas != test.gas {
+			t.Errorf("test %d: gas mismatch: have %d, want %d", i, gas, test.gas)
+		}
+	}
+}
+
+// Tests that every builtin contract resolves to a name and that unnamed or
+// missing contracts fall back to their address.
+func TestPrecompileName(t *testing.T) {
+	sets := map[string]map[common.Address]PrecompiledContract{
+		"frontier":  PrecompiledContracts,
+		"homestead": PrecompiledContractsHomestead,
+		"byzantium": PrecompiledContractsByzantium,
+		"istanbul":  PrecompiledContractsIstanbul,
+		"berlin":    PrecompiledContractsBerlin,
+		"cancun":    PrecompiledContractsCancun,
+		"prague":    PrecompiledContractsPrague,
+		"rip7212":   PrecompiledContractsRIP7212,
+		"ed25519":   PrecompiledContractsEd25519,
+	}
+	for fork, set := range sets {
+		for addr := range set {
+			if name := PrecompileName(addr); name == "" || name == addr.Hex() {
+				t.Errorf("%s: contract %x: missing name", fork, addr)
+			}
+		}
+	}
+	if name := PrecompileName(common.BytesToAddress([]byte{1})); name != "ecrecover" {
+		t.Errorf("ecrecover name mismatch: have %q, want %q", name, "ecrecover")
+	}
+	addr := common.HexToAddress("0xff07")
+	if name := PrecompileName(addr); name != addr.Hex() {
+		t.Errorf("missing contract name mismatch: have %q, want %q", name, addr.Hex())
+	}
+	if err := RegisterPrecompile(addr, &echoPrecompile{}); err != nil {
+		t.Fatalf("failed to register precompile: %v", err)
+	}
+	defer UnregisterPrecompile(addr)
+
+	if name := PrecompileName(addr); name != addr.Hex() {
+		t.Errorf("unnamed contract name mismatch: have %q, want %q", name, addr.Hex())
+	}
+}
+
+// benchmarkPrecompileLookup benchmarks resolving the native contract at the
+// given address, as done on every call.
+func benchmarkPrecompileLookup(addr common.Address, b *testing.B) {
+	rules := params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Precompile(addr, rules)
+	}
+}
+
+func BenchmarkPrecompileLookupBuiltin(b *testing.B) {
+	benchmarkPrecompileLookup(common.BytesToAddress([]byte{1}), b)
+}
+
+func BenchmarkPrecompileLookupMissing(b *testing.B) {
+	benchmarkPrecompileLookup(common.HexToAddress("0x1337000000000000000000000000000000000001"), b)
+}
+
+// Tests that lookups across the low address range resolve exactly the builtin
+// contracts deployed by each fork.
+func TestPrecompileLookupSweep(t *testing.T) {
+	var (
+		frontier  = params.Rules{}
+		homestead = params.Rules{IsHomestead: true}
+		byzantium = params.Rules{IsHomestead: true, IsByzantium: true}
+		istanbul  = params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true}
+		berlin    = params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true, IsBerlin: true}
+	)
+	// lookupRules and lookupSet resolve addresses for forks selected by the chain
+	// rules and for the later sets not reachable through rules yet.
+	lookupRules := func(rules params.Rules) func(common.Address) (PrecompiledContract, bool) {
+		return func(addr common.Address) (PrecompiledContract, bool) { return Precompile(addr, rules) }
+	}
+	lookupSet := func(set map[common.Address]PrecompiledContract) func(common.Address) (PrecompiledContract, bool) {
+		return func(addr common.Address) (PrecompiledContract, bool) { return lookupPrecompile(set, addr) }
+	}
+	tests := []struct {
+		fork   string
+		lookup func(common.Address) (PrecompiledContract, bool)
+		want   []byte
+	}{
+		{"frontier", lookupRules(frontier), []byte{0x01, 0x02, 0x03, 0x04}},
+		{"homestead", lookupRules(homestead), []byte{0x01, 0x02, 0x03, 0x04}},
+		{"byzantium", lookupRules(byzantium), []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}},
+		{"istanbul", lookupRules(istanbul), []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}},
+		{"berlin", lookupRules(berlin), []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}},
+		{"cancun", lookupSet(PrecompiledContractsCancun), []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a}},
+		{"prague", lookupSet(PrecompiledContractsPrague), []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0d, 0x0e, 0x0f}},
+	}
+	for _, test := range tests {
+		want := make(map[byte]bool)
+		for _, addr := range test.want {
+			want[addr] = true
+		}
+		for i := 0; i <= 0x0f; i++ {
+			p, ok := test.lookup(common.BytesToAddress([]byte{byte(i)}))
+			switch {
+			case want[byte(i)] && (!ok || p == nil):
+				t.Errorf("%s: address %#x: contract missing", test.fork, i)
+			case !want[byte(i)] && ok:
+				t.Errorf("%s: address %#x: unexpected contract %T", test.fork, i, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errStatefulPrecompileNoState = errors.New("stateful precompile run without state")
+
+// StateReaderWriter is the subset of the state database available to stateful
+// precompiled contracts.
+type StateReaderWriter interface {
+	GetState(common.Address, common.Hash) common.Hash
+	SetState(common.Address, common.Hash, common.Hash)
+}
+
+// StatefulPrecompiledContract is a native contract with access to the account
+// state, meant for application specific chains. Gas is still charged upfront
+// based on RequiredGas. Stateful contracts are installed by wrapping them with
+// NewStatefulPrecompile.
+type StatefulPrecompiledContract interface {
+	RequiredGas(input []byte) uint64                                                  // RequiredGas calculates the contract gas use
+	Run(state StateReaderWriter, caller common.Address, input []byte) ([]byte, error) // Run runs the contract against the given state
+}
+
+// statefulPrecompile adapts a stateful contract to the precompiled contract
+// interface so it can be registered like any other native contract.
+type statefulPrecompile struct {
+	p StatefulPrecompiledContract
+}
+
+// NewStatefulPrecompile wraps a stateful contract into a precompiled contract.
+// The wrapper is run with the execution context, so the contract runs against
+// the state of the executing EVM, even when wrapped again, e.g. by
+// NewStrictPrecompile.
+func NewStatefulPrecompile(p StatefulPrecompiledContract) PrecompiledContract {
+	return &statefulPrecompile{p: p}
+}
+
+// RequiredGas returns the gas required by the wrapped contract.
+func (s *statefulPrecompile) RequiredGas(input []byte) uint64 {
+	return s.p.RequiredGas(input)
+}
+
+// Run fails, as stateful contracts can only be run with a state.
+func (s *statefulPrecompile) Run(input []byte) ([]byte, error) {
+	return nil, errStatefulPrecompileNoState
+}
+
+// RunWithContext runs the wrapped contract against the state of the context on
+// behalf of its caller.
+func (s *statefulPrecompile) RunWithContext(input []byte, ctx PrecompileContext) ([]byte, error) {
+	if ctx.State == nil {
+		return nil, errStatefulPrecompileNoState
+	}
+	return s.p.Run(ctx.State, ctx.Caller, input)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// mapState is a trivial in-memory StateReaderWriter.
+type mapState map[common.Address]map[common.Hash]common.Hash
+
+func (s mapState) GetState(addr common.Address, key common.Hash) common.Hash {
+	return s[addr][key]
+}
+
+func (s mapState) SetState(addr common.Address, key, value common.Hash) {
+	if s[addr] == nil {
+		s[addr] = make(map[common.Hash]common.Hash)
+	}
+	s[addr][key] = value
+}
+
+// counterPrecompile is an example stateful contract counting the calls made by
+// each caller in its own storage, returning the updated count.
+type counterPrecompile struct {
+	addr common.Address // Account holding the counters
+}
+
+func (c *counterPrecompile) RequiredGas(input []byte) uint64 {
+	return 200
+}
+
+func (c *counterPrecompile) Run(state StateReaderWriter, caller common.Address, input []byte) ([]byte, error) {
+	key := caller.Hash()
+
+	count := state.GetState(c.addr, key).Big()
+	state.SetState(c.addr, key, common.BigToHash(count.Add(count, common.Big1)))
+
+	// Read the counter back to make sure the write is visible within the call
+	return state.GetState(c.addr, key).Bytes(), nil
+}
+
+// Tests that stateful contracts are run against the context state and see
+// their own writes.
+func TestStatefulPrecompile(t *testing.T) {
+	var (
+		addr  = common.HexToAddress("0xff04")
+		p     = NewStatefulPrecompile(&counterPrecompile{addr: addr})
+		state = make(mapState)
+		ctx   = PrecompileContext{State: state}
+		alice = AccountRef(common.HexToAddress("0xa11ce"))
+		bob   = AccountRef(common.HexToAddress("0xb0b"))
+	)
+	for i, caller := range []ContractRef{alice, alice, bob, alice} {
+		contract := NewContract(caller, AccountRef(addr), new(big.Int), 1000)
+
+		ret, _, err := RunPrecompiledContract(p, nil, contract, ctx)
+		if err != nil {
+			t.Fatalf("call %d: failed to run stateful precompile: %v", i, err)
+		}
+		if contract.Gas != 800 {
+			t.Errorf("call %d: gas mismatch: have %d, want %d", i, contract.Gas, 800)
+		}
+		if want := state.GetState(addr, caller.Address().Hash()); common.BytesToHash(ret) != want {
+			t.Errorf("call %d: output mismatch: have %x, want %x", i, ret, want)
+		}
+	}
+	if have := state.GetState(addr, alice.Address().Hash()).Big(); have.Uint64() != 3 {
+		t.Errorf("alice counter mismatch: have %v, want 3", have)
+	}
+	if have := state.GetState(addr, bob.Address().Hash()).Big(); have.Uint64() != 1 {
+		t.Errorf("bob counter mismatch: have %v, want 1", have)
+	}
+	// Running without a state must fail rather than silently succeed
+	contract := NewContract(alice, AccountRef(addr), new(big.Int), 1000)
+	if _, _, err := RunPrecompiledContract(p, nil, contract, PrecompileContext{}); err != errStatefulPrecompileNoState {
+		t.Errorf("error mismatch: have %v, want %v", err, errStatefulPrecompileNoState)
+	}
+}
+
+// Tests that stateful contracts keep their state access when wrapped by the
+// strict input validation.
+func TestStrictStatefulPrecompile(t *testing.T) {
+	var (
+		addr   = common.HexToAddress("0xff04")
+		p      = NewStrictPrecompile(NewStatefulPrecompile(&counterPrecompile{addr: addr}))
+		state  = make(mapState)
+		caller = AccountRef(common.HexToAddress("0xa11ce"))
+	)
+	for i := 1; i <= 2; i++ {
+		contract := NewContract(caller, AccountRef(addr), new(big.Int), 1000)
+
+		ret, _, err := RunPrecompiledContract(p, nil, contract, PrecompileContext{State: state})
+		if err != nil {
+			t.Fatalf("call %d: failed to run wrapped stateful precompile: %v", i, err)
+		}
+		if have := common.BytesToHash(ret).Big(); have.Int64() != int64(i) {
+			t.Errorf("call %d: counter mismatch: have %v, want %d", i, have, i)
+		}
+	}
+	contract := NewContract(caller, AccountRef(addr), new(big.Int), 1000)
+	if _, _, err := RunPrecompiledContract(p, nil, contract, PrecompileContext{}); err != errStatefulPrecompileNoState {
+		t.Errorf("error mismatch: have %v, want %v", err, errStatefulPrecompileNoState)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import "fmt"
+
+// StrictPrecompile wraps a precompiled contract, rejecting inputs which the
+// contract would otherwise silently zero pad or truncate. It is meant to help
+// catching caller bugs; the builtin contract sets stay lenient as consensus
+// requires. The optional extensions of the wrapped contract (naming, refunds and
+// execution context) are forwarded.
+type StrictPrecompile struct {
+	PrecompiledContract
+
+	min    int  // Minimum accepted input length
+	max    int  // Maximum accepted input length, negative if unbounded
+	strict bool // Whether any length validation applies to the contract
+}
+
+// NewStrictPrecompile wraps a builtin precompiled contract with the input length
+// validation matching its encoding. Contracts without fixed size inputs are
+// passed through unvalidated.
+func NewStrictPrecompile(p PrecompiledContract) *StrictPrecompile {
+	s := &StrictPrecompile{PrecompiledContract: p, max: -1}
+	switch p.(type) {
+	case *ecrecover:
+		s.min, s.max, s.strict = 128, 128, true
+	case *bigModExp, *bigModexpEIP2565:
+		s.min, s.strict = 96, true
+	case *bn256Add:
+		s.min, s.max, s.strict = 128, 128, true
+	case *bn256ScalarMul:
+		s.min, s.max, s.strict = 96, 96, true
+	case *p256Verify:
+		s.min, s.max, s.strict = 160, 160, true
+	}
+	return s
+}
+
+// Name returns the name of the wrapped contract, or its type if it is unnamed.
+func (s *StrictPrecompile) Name() string {
+	return precompileName(s.PrecompiledContract, fmt.Sprintf("%T", s.PrecompiledContract))
+}
+
+// Run validates the input length and runs the wrapped contract.
+func (s *StrictPrecompile) Run(input []byte) ([]byte, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+	return s.PrecompiledContract.Run(input)
+}
+
+// RunWithContext validates the input length and runs the wrapped contract,
+// within the given context if the contract requires one.
+func (s *StrictPrecompile) RunWithContext(input []byte, ctx PrecompileContext) ([]byte, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+	if c, ok := s.PrecompiledContract.(ContextualPrecompiledContract); ok {
+		return c.RunWithContext(input, ctx)
+	}
+	return s.PrecompiledContract.Run(input)
+}
+
+// AfterRun returns the refund requested by the wrapped contract, if any.
+func (s *StrictPrecompile) AfterRun(gasUsed uint64) uint64 {
+	if r, ok := s.PrecompiledContract.(RefundingPrecompiledContract); ok {
+		return r.AfterRun(gasUsed)
+	}
+	return 0
+}
+
+// validate checks the input length against the bounds of the wrapped contract.
+func (s *StrictPrecompile) validate(input []byte) error {
+	if !s.strict {
+		return nil
+	}
+	switch {
+	case s.min == s.max && len(input) != s.min:
+		return fmt.Errorf("%s: invalid input length %d, want %d", s.Name(), len(input), s.min)
+	case len(input) < s.min:
+		return fmt.Errorf("%s: input too short (%d < %d)", s.Name(), len(input), s.min)
+	case s.max >= 0 && len(input) > s.max:
+		return fmt.Errorf("%s: input too long (%d > %d)", s.Name(), len(input), s.max)
+	}
+	return nil
+}
@@ -0,0 +1,1795 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// precompiledTest defines the input/output pairs for precompiled contract tests.
+type precompiledTest struct {
+	input, expected string
+	gas             uint64 // expected gas cost, checked only if nonzero
+	name            string
+}
+
+// precompiledFailureTest defines the input/error pairs for precompiled contract
+// failure tests. A nil expectedError accepts any error.
+type precompiledFailureTest struct {
+	input         string
+	expectedError error
+	name          string
+}
+
+// allPrecompiles contains every precompiled contract under test, keyed by the
+// address it is deployed at.
+var allPrecompiles = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModexpEIP2565{},
+	common.BytesToAddress([]byte{6}): &bn256Add{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}): &bn256Pairing{},
+	common.BytesToAddress([]byte{9}): &blake2F{},
+
+	common.BytesToAddress([]byte{0x0a}):    &kzgPointEvaluation{},
+	common.BytesToAddress([]byte{0x0b}):    &bls12381G1Add{},
+	common.BytesToAddress([]byte{0x0d}):    &bls12381G1MultiExp{},
+	common.BytesToAddress([]byte{0x0e}):    &bls12381G2Add{},
+	common.BytesToAddress([]byte{0x0f}):    &bls12381G2Mul{},
+	common.BytesToAddress([]byte{0x10}):    &bls12381G2MultiExp{},
+	common.BytesToAddress([]byte{0x11}):    &bls12381Pairing{},
+	common.BytesToAddress([]byte{0x12}):    &bls12381MapG1{},
+	common.BytesToAddress([]byte{0x13}):    &bls12381MapG2{},
+	common.BytesToAddress([]byte{1, 0x00}): &p256Verify{},
+	common.BytesToAddress([]byte{1, 0x01}): &ed25519Verify{},
+}
+
+// modexpEIP2565Tests are the test data for the modular exponentiation
+// precompiled contract as repriced by EIP 2565.
+var modexpEIP2565Tests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000002003fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2efffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		gas:      1360,
+		name:     "eip_example1",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000020fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2efffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		gas:      1360,
+		name:     "eip_example2",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000040e09ad9675465c53a109fac66a445c91b292d2bb2c5268addb30cd82f80fcb0033ff97c80a5fc6f39193ae969c6ede6710a6b7ac27078a06d90ef1c72e5c85fb502fc9e1f6beb81516545975218075ec2af118cd8798df6e08a147c60fd6095ac2bb02c2908cf4dd7c81f11c289e4bce98f3553768f392a80ce22bf5c4f4a248c6b",
+		expected: "60008f1614cc01dcfb6bfb09c625cf90b47d4468db81b5f8b7a39d42f332eab9b2da8f2d95311648a8f243f4bb13cfb3d8f7f2a3c014122ebb3ed41b02783adc",
+		gas:      200,
+		name:     "nagydani-1-square",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000040e09ad9675465c53a109fac66a445c91b292d2bb2c5268addb30cd82f80fcb0033ff97c80a5fc6f39193ae969c6ede6710a6b7ac27078a06d90ef1c72e5c85fb503fc9e1f6beb81516545975218075ec2af118cd8798df6e08a147c60fd6095ac2bb02c2908cf4dd7c81f11c289e4bce98f3553768f392a80ce22bf5c4f4a248c6b",
+		expected: "4834a46ba565db27903b1c720c9d593e84e4cbd6ad2e64b31885d944f68cd801f92225a8961c952ddf2797fa4701b330c85c4b363798100b921a1a22a46a7fec",
+		gas:      200,
+		name:     "nagydani-1-qube",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000040e09ad9675465c53a109fac66a445c91b292d2bb2c5268addb30cd82f80fcb0033ff97c80a5fc6f39193ae969c6ede6710a6b7ac27078a06d90ef1c72e5c85fb5010001fc9e1f6beb81516545975218075ec2af118cd8798df6e08a147c60fd6095ac2bb02c2908cf4dd7c81f11c289e4bce98f3553768f392a80ce22bf5c4f4a248c6b",
+		expected: "c36d804180c35d4426b57b50c5bfcca5c01856d104564cd513b461d3c8b8409128a5573e416d0ebe38f5f736766d9dc27143e4da981dfa4d67f7dc474cbee6d2",
+		gas:      341,
+		name:     "nagydani-1-pow0x10001",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000080cad7d991a00047dd54d3399b6b0b937c718abddef7917c75b6681f40cc15e2be0003657d8d4c34167b2f0bbbca0ccaa407c2a6a07d50f1517a8f22979ce12a81dcaf707cc0cebfc0ce2ee84ee7f77c38b9281b9822a8d3de62784c089c9b18dcb9a2a5eecbede90ea788a862a9ddd9d609c2c52972d63e289e28f6a590ffbf5102e6d893b80aeed5e6e9ce9afa8a5d5675c93a32ac05554cb20e9951b2c140e3ef4e433068cf0fb73bc9f33af1853f64aa27a0028cbf570d7ac9048eae5dc7b28c87c31e5810f1e7fa2cda6adf9f1076dbc1ec1238560071e7efc4e9565c49be9e7656951985860a558a754594115830bcdb421f741408346dd5997bb01c287087",
+		expected: "981dd99c3b113fae3e3eaa9435c0dc96779a23c12a53d1084b4f67b0b053a27560f627b873e3f16ad78f28c94f14b6392def26e4d8896c5e3c984e50fa0b3aa44f1da78b913187c6128baa9340b1e9c9a0fd02cb78885e72576da4a8f7e5a113e173a7a2889fde9d407bd9f06eb05bc8fc7b4229377a32941a02bf4edcc06d70",
+		gas:      200,
+		name:     "nagydani-2-square",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000080cad7d991a00047dd54d3399b6b0b937c718abddef7917c75b6681f40cc15e2be0003657d8d4c34167b2f0bbbca0ccaa407c2a6a07d50f1517a8f22979ce12a81dcaf707cc0cebfc0ce2ee84ee7f77c38b9281b9822a8d3de62784c089c9b18dcb9a2a5eecbede90ea788a862a9ddd9d609c2c52972d63e289e28f6a590ffbf5103e6d893b80aeed5e6e9ce9afa8a5d5675c93a32ac05554cb20e9951b2c140e3ef4e433068cf0fb73bc9f33af1853f64aa27a0028cbf570d7ac9048eae5dc7b28c87c31e5810f1e7fa2cda6adf9f1076dbc1ec1238560071e7efc4e9565c49be9e7656951985860a558a754594115830bcdb421f741408346dd5997bb01c287087",
+		expected: "d89ceb68c32da4f6364978d62aaa40d7b09b59ec61eb3c0159c87ec3a91037f7dc6967594e530a69d049b64adfa39c8fa208ea970cfe4b7bcd359d345744405afe1cbf761647e32b3184c7fbe87cee8c6c7ff3b378faba6c68b83b6889cb40f1603ee68c56b4c03d48c595c826c041112dc941878f8c5be828154afd4a16311f",
+		gas:      200,
+		name:     "nagydani-2-qube",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000080cad7d991a00047dd54d3399b6b0b937c718abddef7917c75b6681f40cc15e2be0003657d8d4c34167b2f0bbbca0ccaa407c2a6a07d50f1517a8f22979ce12a81dcaf707cc0cebfc0ce2ee84ee7f77c38b9281b9822a8d3de62784c089c9b18dcb9a2a5eecbede90ea788a862a9ddd9d609c2c52972d63e289e28f6a590ffbf51010001e6d893b80aeed5e6e9ce9afa8a5d5675c93a32ac05554cb20e9951b2c140e3ef4e433068cf0fb73bc9f33af1853f64aa27a0028cbf570d7ac9048eae5dc7b28c87c31e5810f1e7fa2cda6adf9f1076dbc1ec1238560071e7efc4e9565c49be9e7656951985860a558a754594115830bcdb421f741408346dd5997bb01c287087",
+		expected: "ad85e8ef13fd1dd46eae44af8b91ad1ccae5b7a1c92944f92a19f21b0b658139e0cabe9c1f679507c2de354bf2c91ebd965d1e633978a830d517d2f6f8dd5fd58065d58559de7e2334a878f8ec6992d9b9e77430d4764e863d77c0f87beede8f2f7f2ab2e7222f85cc9d98b8467f4bb72e87ef2882423ebdb6daf02dddac6db2",
+		gas:      1365,
+		name:     "nagydani-2-pow0x10001",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000100c9130579f243e12451760976261416413742bd7c91d39ae087f46794062b8c239f2a74abf3918605a0e046a7890e049475ba7fbb78f5de6490bd22a710cc04d30088179a919d86c2da62cf37f59d8f258d2310d94c24891be2d7eeafaa32a8cb4b0cfe5f475ed778f45907dc8916a73f03635f233f7a77a00a3ec9ca6761a5bbd558a2318ecd0caa1c5016691523e7e1fa267dd35e70c66e84380bdcf7c0582f540174e572c41f81e93da0b757dff0b0fe23eb03aa19af0bdec3afb474216febaacb8d0381e631802683182b0fe72c28392539850650b70509f54980241dc175191a35d967288b532a7a8223ce2440d010615f70df269501944d4ec16fe4a3cb02d7a85909174757835187cb52e71934e6c07ef43b4c46fc30bbcd0bc72913068267c54a4aabebb493922492820babdeb7dc9b1558fcf7bd82c37c82d3147e455b623ab0efa752fe0b3a67ca6e4d126639e645a0bf417568adbb2a6a4eef62fa1fa29b2a5a43bebea1f82193a7dd98eb483d09bb595af1fa9c97c7f41f5649d976aee3e5e59e2329b43b13bea228d4a93f16ba139ccb511de521ffe747aa2eca664f7c9e33da59075cc335afcd2bf3ae09765f01ab5a7c3e3938ec168b74724b5074247d200d9970382f683d6059b94dbc336603d1dfee714e4b447ac2fa1d99ecb4961da2854e03795ed758220312d101e1e3d87d5313a6d052aebde75110363d",
+		expected: "affc7507ea6d84751ec6b3f0d7b99dbcc263f33330e450d1b3ff0bc3d0874320bf4edd57debd587306988157958cb3cfd369cc0c9c198706f635c9e0f15d047df5cb44d03e2727f26b083c4ad8485080e1293f171c1ed52aef5993a5815c35108e848c951cf1e334490b4a539a139e57b68f44fee583306f5b85ffa57206b3ee5660458858534e5386b9584af3c7f67806e84c189d695e5eb96e1272d06ec2df5dc5fabc6e94b793718c60c36be0a4d031fc84cd658aa72294b2e16fc240aef70cb9e591248e38bd49c5a554d1afa01f38dab72733092f7555334bbef6c8c430119840492380aa95fa025dcf699f0a39669d812b0c6946b6091e6e235337b6f8",
+		gas:      341,
+		name:     "nagydani-3-square",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000100c9130579f243e12451760976261416413742bd7c91d39ae087f46794062b8c239f2a74abf3918605a0e046a7890e049475ba7fbb78f5de6490bd22a710cc04d30088179a919d86c2da62cf37f59d8f258d2310d94c24891be2d7eeafaa32a8cb4b0cfe5f475ed778f45907dc8916a73f03635f233f7a77a00a3ec9ca6761a5bbd558a2318ecd0caa1c5016691523e7e1fa267dd35e70c66e84380bdcf7c0582f540174e572c41f81e93da0b757dff0b0fe23eb03aa19af0bdec3afb474216febaacb8d0381e631802683182b0fe72c28392539850650b70509f54980241dc175191a35d967288b532a7a8223ce2440d010615f70df269501944d4ec16fe4a3cb03d7a85909174757835187cb52e71934e6c07ef43b4c46fc30bbcd0bc72913068267c54a4aabebb493922492820babdeb7dc9b1558fcf7bd82c37c82d3147e455b623ab0efa752fe0b3a67ca6e4d126639e645a0bf417568adbb2a6a4eef62fa1fa29b2a5a43bebea1f82193a7dd98eb483d09bb595af1fa9c97c7f41f5649d976aee3e5e59e2329b43b13bea228d4a93f16ba139ccb511de521ffe747aa2eca664f7c9e33da59075cc335afcd2bf3ae09765f01ab5a7c3e3938ec168b74724b5074247d200d9970382f683d6059b94dbc336603d1dfee714e4b447ac2fa1d99ecb4961da2854e03795ed758220312d101e1e3d87d5313a6d052aebde75110363d",
+		expected: "1b280ecd6a6bf906b806d527c2a831e23b238f89da48449003a88ac3ac7150d6a5e9e6b3be4054c7da11dd1e470ec29a606f5115801b5bf53bc1900271d7c3ff3cd5ed790d1c219a9800437a689f2388ba1a11d68f6a8e5b74e9a3b1fac6ee85fc6afbac599f93c391f5dc82a759e3c6c0ab45ce3f5d25d9b0c1bf94cf701ea6466fc9a478dacc5754e593172b5111eeba88557048bceae401337cd4c1182ad9f700852bc8c99933a193f0b94cf1aedbefc48be3bc93ef5cb276d7c2d5462ac8bb0c8fe8923a1db2afe1c6b90d59c534994a6a633f0ead1d638fdc293486bb634ff2c8ec9e7297c04241a61c37e3ae95b11d53343d4ba2b4cc33d2cfa7eb705e",
+		gas:      341,
+		name:     "nagydani-3-qube",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000100c9130579f243e12451760976261416413742bd7c91d39ae087f46794062b8c239f2a74abf3918605a0e046a7890e049475ba7fbb78f5de6490bd22a710cc04d30088179a919d86c2da62cf37f59d8f258d2310d94c24891be2d7eeafaa32a8cb4b0cfe5f475ed778f45907dc8916a73f03635f233f7a77a00a3ec9ca6761a5bbd558a2318ecd0caa1c5016691523e7e1fa267dd35e70c66e84380bdcf7c0582f540174e572c41f81e93da0b757dff0b0fe23eb03aa19af0bdec3afb474216febaacb8d0381e631802683182b0fe72c28392539850650b70509f54980241dc175191a35d967288b532a7a8223ce2440d010615f70df269501944d4ec16fe4a3cb010001d7a85909174757835187cb52e71934e6c07ef43b4c46fc30bbcd0bc72913068267c54a4aabebb493922492820babdeb7dc9b1558fcf7bd82c37c82d3147e455b623ab0efa752fe0b3a67ca6e4d126639e645a0bf417568adbb2a6a4eef62fa1fa29b2a5a43bebea1f82193a7dd98eb483d09bb595af1fa9c97c7f41f5649d976aee3e5e59e2329b43b13bea228d4a93f16ba139ccb511de521ffe747aa2eca664f7c9e33da59075cc335afcd2bf3ae09765f01ab5a7c3e3938ec168b74724b5074247d200d9970382f683d6059b94dbc336603d1dfee714e4b447ac2fa1d99ecb4961da2854e03795ed758220312d101e1e3d87d5313a6d052aebde75110363d",
+		expected: "37843d7c67920b5f177372fa56e2a09117df585f81df8b300fba245b1175f488c99476019857198ed459ed8d9799c377330e49f4180c4bf8e8f66240c64f65ede93d601f957b95b83efdee1e1bfde74169ff77002eaf078c71815a9220c80b2e3b3ff22c2f358111d816ebf83c2999026b6de50bfc711ff68705d2f40b753424aefc9f70f08d908b5a20276ad613b4ab4309a3ea72f0c17ea9df6b3367d44fb3acab11c333909e02e81ea2ed404a712d3ea96bba87461720e2d98723e7acd0520ac1a5212dbedcd8dc0c1abf61d4719e319ff4758a774790b8d463cdfe131d1b2dcfee52d002694e98e720cb6ae7ccea353bc503269ba35f0f63bf8d7b672a76",
+		gas:      5461,
+		name:     "nagydani-3-pow0x10001",
+	},
+}
+
+// bn256AddTests are the test and benchmark data for the bn256 addition precompiled
+// contract.
+var bn256AddTests = []precompiledTest{
+	{
+		input:    "18b18acfb4c2c30276db5411368e7185b311dd124691610c5d3b74034e093dc9063c909c4720840cb5134cb9f59fa749755796819658d32efc0d288198f3726607c2b7f58a84bd6145f00c9c2bc0bb1a187f20ff2c92963a88019e7c6a014eed06614e20c147e940f2d70da3f74c9a17df361706a4485c742bd6788478fa17d7",
+		expected: "2243525c5efd4b9c3d3c45ac0ca3fe4dd85e830a4ce6b65fa1eeaee202839703301d1d33be6da8e509df21cc35964723180eed7532537db9ae5e7d48f195c915",
+		name:     "chfast1",
+	}, {
+		input:    "2243525c5efd4b9c3d3c45ac0ca3fe4dd85e830a4ce6b65fa1eeaee202839703301d1d33be6da8e509df21cc35964723180eed7532537db9ae5e7d48f195c91518b18acfb4c2c30276db5411368e7185b311dd124691610c5d3b74034e093dc9063c909c4720840cb5134cb9f59fa749755796819658d32efc0d288198f37266",
+		expected: "2bd3e6d0f3b142924f5ca7b49ce5b9d54c4703d7ae5648e61d02268b1a0a9fb721611ce0a6af85915e2f1d70300909ce2e49dfad4a4619c8390cae66cefdb204",
+		name:     "chfast2",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio1",
+	}, {
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio2",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio3",
+	}, {
+		input:    "",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio4",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio5",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		expected: "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		name:     "cdetrio6",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		name:     "cdetrio7",
+	}, {
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		expected: "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		name:     "cdetrio8",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		name:     "cdetrio9",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		name:     "cdetrio10",
+	}, {
+		input:    "0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		expected: "030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4",
+		name:     "cdetrio11",
+	}, {
+		input:    "000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4",
+		name:     "cdetrio12",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d98",
+		expected: "15bf2bb17880144b5d1cd2b1f46eff9d617bffd1ca57c37fb5a49bd84e53cf66049c797f9ce0d17083deb32b5e36f2ea2a212ee036598dd7624c168993d1355f",
+		name:     "cdetrio13",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa92e83f8d734803fc370eba25ed1f6b8768bd6d83887b87165fc2434fe11a830cb00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "cdetrio14",
+	},
+}
+
+// bn256AddInvalidTests are inputs the bn256 addition precompiled contract must
+// reject since one of the points is not on the curve.
+var bn256AddInvalidTests = []precompiledFailureTest{
+	{
+		input: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000003",
+		name: "first_not_on_curve",
+	}, {
+		input: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"1111111111111111111111111111111111111111111111111111111111111111" +
+			"1111111111111111111111111111111111111111111111111111111111111111",
+		name: "second_not_on_curve",
+	},
+}
+
+// bn256ScalarMulTests are the test and benchmark data for the bn256 scalar
+// multiplication precompiled contract.
+var bn256ScalarMulTests = []precompiledTest{
+	{
+		input:    "2bd3e6d0f3b142924f5ca7b49ce5b9d54c4703d7ae5648e61d02268b1a0a9fb721611ce0a6af85915e2f1d70300909ce2e49dfad4a4619c8390cae66cefdb20400000000000000000000000000000000000000000000000011138ce750fa15c2",
+		expected: "070a8d6a982153cae4be29d434e8faef8a47b274a053f5a4ee2a6c9c13c31e5c031b8ce914eba3a9ffb989f9cdd5b0f01943074bf4f0f315690ec3cec6981afc",
+		name:     "chfast1",
+	}, {
+		input:    "070a8d6a982153cae4be29d434e8faef8a47b274a053f5a4ee2a6c9c13c31e5c031b8ce914eba3a9ffb989f9cdd5b0f01943074bf4f0f315690ec3cec6981afc30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd46",
+		expected: "025a6f4181d2b4ea8b724290ffb40156eb0adb514c688556eb79cdea0752c2bb2eff3f31dea215f1eb86023a133a996eb6300b44da664d64251d05381bb8a02e",
+		name:     "chfast2",
+	}, {
+		input:    "025a6f4181d2b4ea8b724290ffb40156eb0adb514c688556eb79cdea0752c2bb2eff3f31dea215f1eb86023a133a996eb6300b44da664d64251d05381bb8a02e183227397098d014dc2822db40c0ac2ecbc0b548b438e5469e10460b6c3e7ea3",
+		expected: "14789d0d4a730b354403b5fac948113739e276c23e0258d8596ee72f9cd9d3230af18a63153e0ec25ff9f2951dd3fa90ed0197bfef6e2a1a62b5095b9d2b4a27",
+		name:     "chfast3",
+	}, {
+		input:    "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f6ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		expected: "2cde5879ba6f13c0b5aa4ef627f159a3347df9722efce88a9afbb20b763b4c411aa7e43076f6aee272755a7f9b84832e71559ba0d2e0b17d5f9f01755e5b0d11",
+		name:     "cdetrio1",
+	}, {
+		input:    "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f630644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000",
+		expected: "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe3163511ddc1c3f25d396745388200081287b3fd1472d8339d5fecb2eae0830451",
+		name:     "cdetrio2",
+	}, {
+		input:    "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f60000000000000000000000000000000100000000000000000000000000000000",
+		expected: "1051acb0700ec6d42a88215852d582efbaef31529b6fcbc3277b5c1b300f5cf0135b2394bb45ab04b8bd7611bd2dfe1de6a4e6e2ccea1ea1955f577cd66af85b",
+		name:     "cdetrio3",
+	}, {
+		input:    "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f60000000000000000000000000000000000000000000000000000000000000009",
+		expected: "1dbad7d39dbc56379f78fac1bca147dc8e66de1b9d183c7b167351bfe0aeab742cd757d51289cd8dbd0acf9e673ad67d0f0a89f912af47ed1be53664f5692575",
+		name:     "cdetrio4",
+	}, {
+		input:    "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f60000000000000000000000000000000000000000000000000000000000000001",
+		expected: "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f6",
+		name:     "cdetrio5",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7cffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		expected: "29e587aadd7c06722aabba753017c093f70ba7eb1f1c0104ec0564e7e3e21f6022b1143f6a41008e7755c71c3d00b6b915d386de21783ef590486d8afa8453b1",
+		name:     "cdetrio6",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000",
+		expected: "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa92e83f8d734803fc370eba25ed1f6b8768bd6d83887b87165fc2434fe11a830cb",
+		name:     "cdetrio7",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c0000000000000000000000000000000100000000000000000000000000000000",
+		expected: "221a3577763877920d0d14a91cd59b9479f83b87a653bb41f82a3f6f120cea7c2752c7f64cdd7f0e494bff7b60419f242210f2026ed2ec70f89f78a4c56a1f15",
+		name:     "cdetrio8",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c0000000000000000000000000000000000000000000000000000000000000009",
+		expected: "228e687a379ba154554040f8821f4e41ee2be287c201aa9c3bc02c9dd12f1e691e0fd6ee672d04cfd924ed8fdc7ba5f2d06c53c1edc30f65f2af5a5b97f0a76a",
+		name:     "cdetrio9",
+	}, {
+		input:    "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c0000000000000000000000000000000000000000000000000000000000000001",
+		expected: "17c139df0efee0f766bc0204762b774362e4ded88953a39ce849a8a7fa163fa901e0559bacb160664764a357af8a9fe70baa9258e0b959273ffc5718c6d4cc7c",
+		name:     "cdetrio10",
+	}, {
+		input:    "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d98ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		expected: "00a1a234d08efaa2616607e31eca1980128b00b415c845ff25bba3afcb81dc00242077290ed33906aeb8e42fd98c41bcb9057ba03421af3f2d08cfc441186024",
+		name:     "cdetrio11",
+	}, {
+		input:    "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d9830644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000",
+		expected: "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b8692929ee761a352600f54921df9bf472e66217e7bb0cee9032e00acc86b3c8bfaf",
+		name:     "cdetrio12",
+	}, {
+		input:    "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d980000000000000000000000000000000100000000000000000000000000000000",
+		expected: "1071b63011e8c222c5a771dfa03c2e11aac9666dd097f2c620852c3951a4376a2f46fe2f73e1cf310a168d56baa5575a8319389d7bfa6b29ee2d908305791434",
+		name:     "cdetrio13",
+	}, {
+		input:    "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d980000000000000000000000000000000000000000000000000000000000000009",
+		expected: "19f75b9dd68c080a688774a6213f131e3052bd353a304a189d7a2ee367e3c2582612f545fb9fc89fde80fd81c68fc7dcb27fea5fc124eeda69433cf5c46d2d7f",
+		name:     "cdetrio14",
+	}, {
+		input:    "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d980000000000000000000000000000000000000000000000000000000000000001",
+		expected: "039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d98",
+		name:     "cdetrio15",
+	},
+}
+
+// bn256ScalarMulInvalidTests are inputs the bn256 scalar multiplication
+// precompiled contract must reject since the point is not on the curve.
+var bn256ScalarMulInvalidTests = []precompiledFailureTest{
+	{
+		input: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000003" +
+			"0000000000000000000000000000000000000000000000000000000000000002",
+		name: "not_on_curve",
+	}, {
+		input: "1111111111111111111111111111111111111111111111111111111111111111",
+		name:  "short_not_on_curve",
+	},
+}
+
+// bn256PairingTests are the test and benchmark data for the bn256 pairing check
+// precompiled contract.
+var bn256PairingTests = []precompiledTest{
+	{
+		input:    "1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f593034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf704bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a416782bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c2032c61a830e3c17286de9462bf242fca2883585b93870a73853face6a6bf411198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "jeff1",
+	}, {
+		input:    "2eca0c7238bf16e83e7a1e6c5d49540685ff51380f309842a98561558019fc0203d3260361bb8451de5ff5ecd17f010ff22f5c31cdf184e9020b06fa5997db841213d2149b006137fcfb23036606f848d638d576a120ca981b5b1a5f9300b3ee2276cf730cf493cd95d64677bbb75fc42db72513a4c1e387b476d056f80aa75f21ee6226d31426322afcda621464d0611d226783262e21bb3bc86b537e986237096df1f82dff337dd5972e32a8ad43e28a78a96a823ef1cd4debe12b6552ea5f06967a1237ebfeca9aaae0d6d0bab8e28c198c5a339ef8a2407e31cdac516db922160fa257a5fd5b280642ff47b65eca77e626cb685c84fa6d3b6882a283ddd1198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "jeff2",
+	}, {
+		input:    "0f25929bcb43d5a57391564615c9e70a992b10eafa4db109709649cf48c50dd216da2f5cb6be7a0aa72c440c53c9bbdfec6c36c7d515536431b3a865468acbba2e89718ad33c8bed92e210e81d1853435399a271913a6520736a4729cf0d51eb01a9e2ffa2e92599b68e44de5bcf354fa2642bd4f26b259daa6f7ce3ed57aeb314a9a87b789a58af499b314e13c3d65bede56c07ea2d418d6874857b70763713178fb49a2d6cd347dc58973ff49613a20757d0fcc22079f9abd10c3baee245901b9e027bd5cfc2cb5db82d4dc9677ac795ec500ecd47deee3b5da006d6d049b811d7511c78158de484232fc68daf8a45cf217d1c2fae693ff5871e8752d73b21198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "jeff3",
+	}, {
+		input:    "2f2ea0b3da1e8ef11914acf8b2e1b32d99df51f5f4f206fc6b947eae860eddb6068134ddb33dc888ef446b648d72338684d678d2eb2371c61a50734d78da4b7225f83c8b6ab9de74e7da488ef02645c5a16a6652c3c71a15dc37fe3a5dcb7cb122acdedd6308e3bb230d226d16a105295f523a8a02bfc5e8bd2da135ac4c245d065bbad92e7c4e31bf3757f1fe7362a63fbfee50e7dc68da116e67d600d9bf6806d302580dc0661002994e7cd3a7f224e7ddc27802777486bf80f40e4ca3cfdb186bac5188a98c45e6016873d107f5cd131f3a3e339d0375e58bd6219347b008122ae2b09e539e152ec5364e7e2204b03d11d3caa038bfc7cd499f8176aacbee1f39e4e4afc4bc74790a4a028aff2c3d2538731fb755edefd8cb48d6ea589b5e283f150794b6736f670d6a1033f9b46c6f5204f50813eb85c8dc4b59db1c5d39140d97ee4d2b36d99bc49974d18ecca3e7ad51011956051b464d9e27d46cc25e0764bb98575bd466d32db7b15f582b2d5c452b36aa394b789366e5e3ca5aabd415794ab061441e51d01e94640b7e3084a07e02c78cf3103c542bc5b298669f211b88da1679b0b64a63b7e0e7bfe52aae524f73a55be7fe70c7e9bfc94b4cf0da1213d2149b006137fcfb23036606f848d638d576a120ca981b5b1a5f9300b3ee2276cf730cf493cd95d64677bbb75fc42db72513a4c1e387b476d056f80aa75f21ee6226d31426322afcda621464d0611d226783262e21bb3bc86b537e986237096df1f82dff337dd5972e32a8ad43e28a78a96a823ef1cd4debe12b6552ea5f",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "jeff4",
+	}, {
+		input:    "20a754d2071d4d53903e3b31a7e98ad6882d58aec240ef981fdf0a9d22c5926a29c853fcea789887315916bbeb89ca37edb355b4f980c9a12a94f30deeed30211213d2149b006137fcfb23036606f848d638d576a120ca981b5b1a5f9300b3ee2276cf730cf493cd95d64677bbb75fc42db72513a4c1e387b476d056f80aa75f21ee6226d31426322afcda621464d0611d226783262e21bb3bc86b537e986237096df1f82dff337dd5972e32a8ad43e28a78a96a823ef1cd4debe12b6552ea5f1abb4a25eb9379ae96c84fff9f0540abcfc0a0d11aeda02d4f37e4baf74cb0c11073b3ff2cdbb38755f8691ea59e9606696b3ff278acfc098fa8226470d03869217cee0a9ad79a4493b5253e2e4e3a39fc2df38419f230d341f60cb064a0ac290a3d76f140db8418ba512272381446eb73958670f00cf46f1d9e64cba057b53c26f64a8ec70387a13e41430ed3ee4a7db2059cc5fc13c067194bcc0cb49a98552fd72bd9edb657346127da132e5b82ab908f5816c826acb499e22f2412d1a2d70f25929bcb43d5a57391564615c9e70a992b10eafa4db109709649cf48c50dd2198a1f162a73261f112401aa2db79c7dab1533c9935c77290a6ce3b191f2318d198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "jeff5",
+	}, {
+		input:    "1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f593034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf704bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a416782bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c103188585e2364128fe25c70558f1560f4f9350baf3959e603cc91486e110936198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "jeff6",
+	}, { // ecpairing_empty_data_insufficient_gas
+		input:    "",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "empty_data",
+	}, { // ecpairing_one_point_insufficient_gas
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "one_point",
+	}, { // ecpairing_two_point_match_2
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "two_point_match_2",
+	}, { // ecpairing_two_point_match_3
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "two_point_match_3",
+	}, { // ecpairing_two_point_match_4
+		input:    "105456a333e6d636854f987ea7bb713dfd0ae8371a72aea313ae0c32c0bf10160cf031d41b41557f3e7e3ba0c51bebe5da8e6ecd855ec50fc87efcdeac168bcc0476be093a6d2b4bbf907172049874af11e1b6267606e00804d3ff0037ec57fd3010c68cb50161b7d1d96bb71edfec9880171954e56871abf3d93cc94d745fa114c059d74e5b6c4ec14ae5864ebe23a71781d86c29fb8fb6cce94f70d3de7a2101b33461f39d9e887dbb100f170a2345dde3c07e256d1dfa2b657ba5cd030427000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000021a2c3013d2ea92e13c800cde68ef56a294b883f6ac35d25f587c09b1b3c635f7290158a80cd3d66530f74dc94c94adb88f5cdb481acca997b6e60071f08a115f2f997f3dbd66a7afe07fe7862ce239edba9e05c5afff7f8a1259c9733b2dfbb929d1691530ca701b4a106054688728c9972c8512e9789e9567aae23e302ccd75",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "two_point_match_4",
+	}, {
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "ten_point_match_1",
+	}, {
+		input:    "00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002203e205db4f19b37b60121b83a7333706db86431c6d835849957ed8c3928ad7927dc7234fd11d3e8c36c59277c3e6f149d5cd3cfa9a62aee49f8130962b4b3b9195e8aa5b7827463722b8c153931579d3505566b4edf48d498e185f0509de15204bb53b8977e5f92a0bc372742c4830944a59b4fe6b1c0466e2a6dad122b5d2e030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd31a76dae6d3272396d0cbe61fced2bc532edac647851e3ac53ce1cc9c7e645a83198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "ten_point_match_2",
+	}, { // ecpairing_two_point_match_4
+		input:    "105456a333e6d636854f987ea7bb713dfd0ae8371a72aea313ae0c32c0bf10160cf031d41b41557f3e7e3ba0c51bebe5da8e6ecd855ec50fc87efcdeac168bcc0476be093a6d2b4bbf907172049874af11e1b6267606e00804d3ff0037ec57fd3010c68cb50161b7d1d96bb71edfec9880171954e56871abf3d93cc94d745fa114c059d74e5b6c4ec14ae5864ebe23a71781d86c29fb8fb6cce94f70d3de7a2101b33461f39d9e887dbb100f170a2345dde3c07e256d1dfa2b657ba5cd030427000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000021a2c3013d2ea92e13c800cde68ef56a294b883f6ac35d25f587c09b1b3c635f7290158a80cd3d66530f74dc94c94adb88f5cdb481acca997b6e60071f08a115f2f997f3dbd66a7afe07fe7862ce239edba9e05c5afff7f8a1259c9733b2dfbb929d1691530ca701b4a106054688728c9972c8512e9789e9567aae23e302ccd75",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "ten_point_match_3",
+	},
+}
+
+// bn256PairingInvalidTests are inputs the bn256 pairing check precompiled
+// contract must reject.
+var bn256PairingInvalidTests = []precompiledFailureTest{
+	{
+		input: "1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f59",
+		name:  "bad_length_short",
+	}, {
+		input: "1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f593034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf704bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a416782bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c2032c61a830e3c17286de9462bf242fca2883585b93870a73853face6a6bf411198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00",
+		name:  "bad_length_long",
+	},
+}
+
+// blake2FTests are the test data for the BLAKE2 F compression function
+// precompiled contract, taken from EIP 152.
+var blake2FTests = []precompiledTest{
+	{
+		input:    "0000000048c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b",
+		name:     "vector_4",
+	}, {
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		name:     "vector_5",
+	}, {
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000",
+		expected: "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735",
+		name:     "vector_6",
+	}, {
+		input:    "0000000148c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "b63a380cb2897d521994a85234ee2c181b5f844d2c624c002677e9703449d2fba551b3a8333bcdf5f2f7e08993d53923de3d64fcc68c034e717b9293fed7a421",
+		name:     "vector_7",
+	},
+}
+
+// blake2FInvalidTests are malformed inputs the BLAKE2 F compression function
+// pointEvaluationTests are the test data for the KZG point evaluation
+// precompiled contract.
+var pointEvaluationTests = []precompiledTest{
+	{
+		input: "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b" +
+			"564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306" +
+			"24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a1" +
+			"8f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481b" +
+			"c22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7" +
+			"873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a" +
+			"98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a",
+		expected: "000000000000000000000000000000000000000000000000000000000000100073eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		gas:      50000,
+		name:     "pointEvaluation1",
+	},
+}
+
+// pointEvaluationInvalidTests are the failure tests of the KZG point
+// evaluation precompiled contract.
+var pointEvaluationInvalidTests = []precompiledFailureTest{
+	{
+		input: "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549a" +
+			"564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306" +
+			"24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a1" +
+			"8f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481b" +
+			"c22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7" +
+			"873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a" +
+			"98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a",
+		expectedError: errBlobVerifyMismatchedVersion,
+		name:          "mismatched_versioned_hash",
+	}, {
+		input: "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b" +
+			"564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306" +
+			"24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a0" +
+			"8f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481b" +
+			"c22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7" +
+			"873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a" +
+			"98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a",
+		expectedError: errBlobVerifyKZGProof,
+		name:          "invalid_claim",
+	}, {
+		input: "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b" +
+			"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001" +
+			"24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a1" +
+			"8f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481b" +
+			"c22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7" +
+			"873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a" +
+			"98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a",
+		expectedError: errBlobVerifyKZGProof,
+		name:          "non_canonical_point",
+	}, {
+		input:         "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306",
+		expectedError: errBlobVerifyInvalidInputLength,
+		name:          "short_input",
+	},
+}
+
+// p256VerifyTests are the test data for the secp256r1 signature verification
+// precompiled contract. All but the first entry are expected to fail silently.
+var p256VerifyTests = []precompiledTest{
+	{
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "valid",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1be" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "tampered_hash",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550677" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "tampered_r",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf5" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "tampered_s",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462298",
+		expected: "",
+		name:     "wrong_key",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "zero_r",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "s_equals_order",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299",
+		expected: "",
+		name:     "x_out_of_range",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d44622",
+		expected: "",
+		name:     "short_input",
+	}, {
+		input: "af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf" +
+			"53c3eae047b17bc92904220dbb5ffc1800b2f7d90efbd1134138be8ceb550676" +
+			"a25bfb1d485d8ee31343e70a407e33920087a1d602ddf3d6fc30eee282640cf4" +
+			"60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6" +
+			"7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299" +
+			"00",
+		expected: "",
+		name:     "long_input",
+	},
+}
+
+// precompiled contract must reject, taken from EIP 152.
+var blake2FInvalidTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBlake2FInvalidInputLength,
+		name:          "empty_input",
+	}, {
+		input:         "00000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expectedError: errBlake2FInvalidInputLength,
+		name:          "less_than_213_bytes_input",
+	}, {
+		input:         "000000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expectedError: errBlake2FInvalidInputLength,
+		name:          "more_than_213_bytes_input",
+	}, {
+		input:         "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000002",
+		expectedError: errBlake2FInvalidFinalFlag,
+		name:          "malformed_final_block_indicator_flag",
+	},
+}
+
+// jsonPrecompiledTest is the JSON encoding of a precompiledTest.
+type jsonPrecompiledTest struct {
+	Input, Expected string
+	Gas             uint64
+	Name            string
+}
+
+// jsonPrecompiledFailureTest is the JSON encoding of a precompiledFailureTest.
+type jsonPrecompiledFailureTest struct {
+	Input         string
+	ExpectedError string
+	Name          string
+}
+
+// loadJson loads the precompiled contract tests from testdata/precompiles.
+func loadJson(name string) ([]precompiledTest, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/%v.json", name))
+	if err != nil {
+		return nil, err
+	}
+	var cases []jsonPrecompiledTest
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, err
+	}
+	tests := make([]precompiledTest, len(cases))
+	for i, c := range cases {
+		tests[i] = precompiledTest{input: c.Input, expected: c.Expected, gas: c.Gas, name: c.Name}
+	}
+	return tests, nil
+}
+
+// loadJsonFail loads the precompiled contract failure tests from testdata/precompiles.
+func loadJsonFail(name string) ([]precompiledFailureTest, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/fail-%v.json", name))
+	if err != nil {
+		return nil, err
+	}
+	var cases []jsonPrecompiledFailureTest
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, err
+	}
+	tests := make([]precompiledFailureTest, len(cases))
+	for i, c := range cases {
+		tests[i] = precompiledFailureTest{input: c.Input, expectedError: errors.New(c.ExpectedError), name: c.Name}
+	}
+	return tests, nil
+}
+
+// testJson runs the precompiled contract tests stored in the named JSON file.
+func testJson(name, addr string, t *testing.T) {
+	tests, err := loadJson(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiled(addr, test, t)
+	}
+}
+
+// testJsonFail runs the precompiled contract failure tests stored in the named
+// JSON file.
+func testJsonFail(name, addr string, t *testing.T) {
+	tests, err := loadJsonFail(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiledFailure(addr, test, t)
+	}
+}
+
+func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
+	testPrecompiledContract(allPrecompiles[common.HexToAddress(addr)], test, t)
+}
+
+// testPrecompiledContract runs a single test against the given contract, for
+// contracts not deployed in allPrecompiles.
+func testPrecompiledContract(p PrecompiledContract, test precompiledTest, t *testing.T) {
+	in := common.Hex2Bytes(test.input)
+	contract := NewContract(AccountRef(common.HexToAddress("1337")),
+		nil, new(big.Int), p.RequiredGas(in))
+
+	if test.gas != 0 && p.RequiredGas(in) != test.gas {
+		t.Errorf("%s: expected gas %d, got %d", test.name, test.gas, p.RequiredGas(in))
+	}
+	if res, _, err := RunPrecompiledContract(p, in, contract, PrecompileContext{}); err != nil {
+		t.Errorf("%s-Gas=%d: %v", test.name, p.RequiredGas(in), err)
+	} else if common.Bytes2Hex(res) != test.expected {
+		t.Errorf("%s-Gas=%d: expected %v, got %v", test.name, p.RequiredGas(in), test.expected, common.Bytes2Hex(res))
+	}
+}
+
+func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
+	p := allPrecompiles[common.HexToAddress(addr)]
+	in := common.Hex2Bytes(test.input)
+	contract := NewContract(AccountRef(common.HexToAddress("1337")),
+		nil, new(big.Int), p.RequiredGas(in))
+
+	res, _, err := RunPrecompiledContract(p, in, contract, PrecompileContext{})
+	switch {
+	case err == nil:
+		t.Errorf("%s: expected error, got output %x", test.name, res)
+	case test.expectedError != nil && err.Error() != test.expectedError.Error():
+		t.Errorf("%s: expected error %v, got %v", test.name, test.expectedError, err)
+	}
+}
+
+// Tests the sample inputs from the ModExp EIP 198, priced as per EIP 198.
+func TestPrecompiledModExp(t *testing.T) {
+	tests, err := loadJson("modexp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiledContract(&bigModExp{}, test, t)
+	}
+}
+
+// Tests the sample inputs from the ModExp EIP 198, priced as per EIP 2565.
+func TestPrecompiledModExpEIP2565(t *testing.T) {
+	for _, test := range modexpEIP2565Tests {
+		testPrecompiled("05", test, t)
+	}
+}
+
+// Tests the modexp corner cases around zero length and zero valued moduli.
+func TestPrecompiledModExpEIP2565Zero(t *testing.T) {
+	tests := []precompiledTest{
+		{
+			input: "0000000000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000000",
+			expected: "",
+			gas:      200,
+			name:     "zero_lengths",
+		}, {
+			input: "0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000000" +
+				"0303",
+			expected: "",
+			name:     "zero_modulus_length",
+		}, {
+			input: "0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"0303" + "00000000",
+			expected: "00000000",
+			name:     "zero_modulus_value",
+		}, {
+			input: "0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"0303" + "000000ff",
+			expected: "0000001b",
+			name:     "left_padded_result",
+		},
+	}
+	for _, test := range tests {
+		testPrecompiled("05", test, t)
+	}
+}
+
+// Tests the sample inputs from the elliptic curve addition EIP 196.
+func TestPrecompiledBn256Add(t *testing.T) {
+	for _, test := range bn256AddTests {
+		testPrecompiled("06", test, t)
+	}
+}
+
+// Tests that points not on the curve are rejected by the addition precompile.
+func TestPrecompiledBn256AddInvalid(t *testing.T) {
+	for _, test := range bn256AddInvalidTests {
+		testPrecompiledFailure("06", test, t)
+	}
+}
+
+// Tests the sample inputs from the elliptic curve scalar multiplication EIP 196.
+func TestPrecompiledBn256ScalarMul(t *testing.T) {
+	for _, test := range bn256ScalarMulTests {
+		testPrecompiled("07", test, t)
+	}
+}
+
+// Tests that points not on the curve are rejected by the scalar multiplication
+// precompile.
+func TestPrecompiledBn256ScalarMulInvalid(t *testing.T) {
+	for _, test := range bn256ScalarMulInvalidTests {
+		testPrecompiledFailure("07", test, t)
+	}
+}
+
+// Tests the sample inputs from the elliptic curve pairing check EIP 197.
+func TestPrecompiledBn256Pairing(t *testing.T) {
+	for _, test := range bn256PairingTests {
+		testPrecompiled("08", test, t)
+	}
+}
+
+// Tests that inputs of invalid length are rejected by the pairing check precompile.
+func TestPrecompiledBn256PairingInvalid(t *testing.T) {
+	for _, test := range bn256PairingInvalidTests {
+		testPrecompiledFailure("08", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLAKE2 F compression function EIP 152.
+func TestPrecompiledBlake2F(t *testing.T) {
+	for _, test := range blake2FTests {
+		testPrecompiled("09", test, t)
+	}
+}
+
+// Tests that malformed inputs are rejected by the BLAKE2 F compression function.
+func TestPrecompiledBlake2FInvalid(t *testing.T) {
+	for _, test := range blake2FInvalidTests {
+		testPrecompiledFailure("09", test, t)
+	}
+}
+
+// Tests that signatures with out of range values are soft failures of the
+// ecrecover precompile, returning no output and no error.
+func TestPrecompiledEcrecoverInvalid(t *testing.T) {
+	in := common.Hex2Bytes("38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+		"000000000000000000000000000000000000000000000000000000000000001f" +
+		"38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	p := allPrecompiles[common.HexToAddress("01")]
+	contract := NewContract(AccountRef(common.HexToAddress("1337")),
+		nil, new(big.Int), p.RequiredGas(in))
+
+	res, _, err := RunPrecompiledContract(p, in, contract, PrecompileContext{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(res) != 0 {
+		t.Fatalf("expected empty output, got %x", res)
+	}
+}
+
+// Tests the sample inputs of the KZG point evaluation.
+func TestPrecompiledPointEvaluation(t *testing.T) {
+	for _, test := range pointEvaluationTests {
+		testPrecompiled("0a", test, t)
+	}
+}
+
+// Tests that the KZG point evaluation rejects invalid inputs.
+func TestPrecompiledPointEvaluationInvalid(t *testing.T) {
+	for _, test := range pointEvaluationInvalidTests {
+		testPrecompiledFailure("0a", test, t)
+	}
+}
+
+// Tests the sample inputs of the secp256r1 signature verification, including
+// tampered signatures and malformed encodings.
+func TestPrecompiledP256Verify(t *testing.T) {
+	for _, test := range p256VerifyTests {
+		testPrecompiled("0100", test, t)
+	}
+}
+
+// Tests that the precompiled contract set is selected according to the active
+// fork rules.
+func TestActivePrecompiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules params.Rules
+		want  []byte
+	}{
+		{"frontier", params.Rules{}, []byte{1, 2, 3, 4}},
+		{"homestead", params.Rules{IsHomestead: true, IsEIP150: true, IsEIP155: true, IsEIP158: true}, []byte{1, 2, 3, 4}},
+		{"byzantium", params.Rules{IsHomestead: true, IsByzantium: true}, []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"istanbul", params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true}, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"berlin", params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true, IsBerlin: true}, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	}
+	for _, test := range tests {
+		have := ActivePrecompiles(test.rules)
+		if len(have) != len(test.want) {
+			t.Errorf("%s: address count mismatch: have %d, want %d", test.name, len(have), len(test.want))
+			continue
+		}
+		for i, addr := range test.want {
+			if have[i] != common.BytesToAddress([]byte{addr}) {
+				t.Errorf("%s: address %d mismatch: have %x, want %x", test.name, i, have[i], addr)
+			}
+		}
+		contracts := PrecompiledContractsForConfig(test.rules)
+		if len(contracts) != len(test.want) {
+			t.Errorf("%s: contract count mismatch: have %d, want %d", test.name, len(contracts), len(test.want))
+		}
+	}
+}
+
+// Tests that the interpreter dispatches calls only to the builtin contracts of
+// the fork active at the block being executed.
+func TestPrecompileForkActivation(t *testing.T) {
+	config := &params.ChainConfig{
+		ChainId:        big.NewInt(1),
+		HomesteadBlock: big.NewInt(0),
+		ByzantiumBlock: big.NewInt(10),
+		IstanbulBlock:  big.NewInt(20),
+		BerlinBlock:    big.NewInt(30),
+	}
+	tests := []struct {
+		addr   string
+		test   precompiledTest
+		active uint64 // First block the contract is active at
+	}{
+		{"05", modexpEIP2565Tests[0], 10},
+		{"06", bn256AddTests[0], 10},
+		{"09", blake2FTests[0], 20},
+	}
+	for _, test := range tests {
+		addr := common.HexToAddress(test.addr)
+
+		// Check the block before activation, the activation block and a block
+		// past the last fork, contracts must never disappear once activated
+		for _, number := range []uint64{test.active - 1, test.active, 40} {
+			var (
+				env      = NewEVM(Context{BlockNumber: new(big.Int).SetUint64(number)}, nil, config, Config{})
+				contract = NewContract(AccountRef(common.HexToAddress("1337")), AccountRef(addr), new(big.Int), 1000000)
+			)
+			contract.CodeAddr = &addr
+
+			ret, err := env.Interpreter().Run(contract, common.Hex2Bytes(test.test.input))
+			if err != nil {
+				t.Fatalf("%s at block %d: run failed: %v", test.addr, number, err)
+			}
+			switch {
+			case number < test.active && len(ret) != 0:
+				t.Errorf("%s at block %d: inactive contract ran: %x", test.addr, number, ret)
+			case number >= test.active && common.Bytes2Hex(ret) != test.test.expected:
+				t.Errorf("%s at block %d: output mismatch: have %x, want %s", test.addr, number, ret, test.test.expected)
+			}
+		}
+	}
+}
+
+// Tests that the Homestead set holds the four original contracts and nothing
+// introduced later, and that it is selected for Homestead rules.
+func TestPrecompiledContractsHomestead(t *testing.T) {
+	for _, addr := range []byte{1, 2, 3, 4} {
+		if _, ok := PrecompiledContractsHomestead[common.BytesToAddress([]byte{addr})]; !ok {
+			t.Errorf("contract %#x missing", addr)
+		}
+	}
+	if _, ok := PrecompiledContractsHomestead[common.BytesToAddress([]byte{5})]; ok {
+		t.Errorf("modexp present before Byzantium")
+	}
+	if len(PrecompiledContractsHomestead) != 4 {
+		t.Errorf("contract count mismatch: have %d, want 4", len(PrecompiledContractsHomestead))
+	}
+	contracts := PrecompiledContractsForConfig(params.Rules{IsHomestead: true})
+	if reflect.ValueOf(contracts).Pointer() != reflect.ValueOf(PrecompiledContractsHomestead).Pointer() {
+		t.Errorf("homestead rules selected the wrong set")
+	}
+}
+
+// Tests that modexp is priced by EIP-198 from Byzantium and by EIP-2565 from
+// Berlin on.
+func TestModexpForkPricing(t *testing.T) {
+	var (
+		addr = common.BytesToAddress([]byte{5})
+		in   = common.Hex2Bytes(modexpEIP2565Tests[0].input)
+	)
+	tests := []struct {
+		name  string
+		rules params.Rules
+		gas   uint64
+	}{
+		{"byzantium", params.Rules{IsHomestead: true, IsByzantium: true}, 13056},
+		{"istanbul", params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true}, 13056},
+		{"berlin", params.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true, IsBerlin: true}, 1360},
+	}
+	for _, test := range tests {
+		p := PrecompiledContractsForConfig(test.rules)[addr]
+		if p == nil {
+			t.Errorf("%s: modexp missing", test.name)
+			continue
+		}
+		if have := p.RequiredGas(in); have != test.gas {
+			t.Errorf("%s: gas mismatch: have %d, want %d", test.name, have, test.gas)
+		}
+	}
+}
+
+// Tests that the Berlin set holds exactly the contracts at 0x01 to 0x09, with
+// modexp priced by EIP-2565, and that it is selected for Berlin rules.
+func TestPrecompiledContractsBerlin(t *testing.T) {
+	for addr := byte(1); addr <= 9; addr++ {
+		if _, ok := PrecompiledContractsBerlin[common.BytesToAddress([]byte{addr})]; !ok {
+			t.Errorf("contract %#x missing", addr)
+		}
+	}
+	if len(PrecompiledContractsBerlin) != 9 {
+		t.Errorf("contract count mismatch: have %d, want 9", len(PrecompiledContractsBerlin))
+	}
+	if p := PrecompiledContractsBerlin[common.BytesToAddress([]byte{5})]; reflect.TypeOf(p) != reflect.TypeOf(&bigModexpEIP2565{}) {
+		t.Errorf("modexp implementation mismatch: have %T, want %T", p, &bigModexpEIP2565{})
+	}
+	contracts := PrecompiledContractsForConfig(params.TestBerlinChainConfig.Rules(new(big.Int)))
+	if reflect.ValueOf(contracts).Pointer() != reflect.ValueOf(PrecompiledContractsBerlin).Pointer() {
+		t.Errorf("berlin rules selected the wrong set")
+	}
+}
+
+// refundingPrecompile is a mock native contract requesting a fixed refund.
+type refundingPrecompile struct {
+	refund uint64
+	fail   bool
+}
+
+func (c *refundingPrecompile) RequiredGas(input []byte) uint64 {
+	return 1000
+}
+
+func (c *refundingPrecompile) Run(in []byte) ([]byte, error) {
+	if c.fail {
+		return nil, errors.New("failed")
+	}
+	return in, nil
+}
+
+func (c *refundingPrecompile) AfterRun(gasUsed uint64) uint64 {
+	return c.refund
+}
+
+// Tests that gas refunds requested by a precompiled contract are surfaced and
+// capped at half the gas used.
+func TestPrecompiledRefund(t *testing.T) {
+	tests := []struct {
+		p    *refundingPrecompile
+		want uint64
+	}{
+		{&refundingPrecompile{refund: 0}, 0},
+		{&refundingPrecompile{refund: 300}, 300},
+		{&refundingPrecompile{refund: 500}, 500},
+		{&refundingPrecompile{refund: 501}, 500},
+		{&refundingPrecompile{refund: 5000}, 500},
+		{&refundingPrecompile{refund: 300, fail: true}, 0},
+	}
+	for i, test := range tests {
+		contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 1000)
+		_, refund, _ := RunPrecompiledContract(test.p, nil, contract, PrecompileContext{})
+		if refund != test.want {
+			t.Errorf("test %d: refund mismatch: have %d, want %d", i, refund, test.want)
+		}
+	}
+	// Builtin contracts never refund
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 100)
+	if _, refund, _ := RunPrecompiledContract(&dataCopy{}, []byte{1}, contract, PrecompileContext{}); refund != 0 {
+		t.Errorf("builtin refund mismatch: have %d, want 0", refund)
+	}
+}
+
+// Tests that the chunked sha256 hashing yields the same digests as hashing the
+// input in one go, including around the chunk boundaries.
+func TestSha256Chunked(t *testing.T) {
+	sizes := []int{0, 1, 55, 64, sha256ChunkSize - 1, sha256ChunkSize, sha256ChunkSize + 1, 3*sha256ChunkSize + 17}
+	for _, size := range sizes {
+		in := make([]byte, size)
+		for i := range in {
+			in[i] = byte(i)
+		}
+		want := sha256.Sum256(in)
+		if have := sha256Chunked(in); !bytes.Equal(have, want[:]) {
+			t.Errorf("size %d: digest mismatch: have %x, want %x", size, have, want)
+		}
+	}
+}
+
+func benchmarkSha256Sum256(size int, b *testing.B) {
+	in := make([]byte, size)
+	b.SetBytes(int64(size))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sha256.Sum256(in)
+	}
+}
+
+func benchmarkSha256Chunked(size int, b *testing.B) {
+	in := make([]byte, size)
+	b.SetBytes(int64(size))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sha256Chunked(in)
+	}
+}
+
+func BenchmarkSha256Sum256_1KB(b *testing.B)   { benchmarkSha256Sum256(1024, b) }
+func BenchmarkSha256Sum256_1MB(b *testing.B)   { benchmarkSha256Sum256(1024*1024, b) }
+func BenchmarkSha256Sum256_16MB(b *testing.B)  { benchmarkSha256Sum256(16*1024*1024, b) }
+func BenchmarkSha256Chunked_1KB(b *testing.B)  { benchmarkSha256Chunked(1024, b) }
+func BenchmarkSha256Chunked_1MB(b *testing.B)  { benchmarkSha256Chunked(1024*1024, b) }
+func BenchmarkSha256Chunked_16MB(b *testing.B) { benchmarkSha256Chunked(16*1024*1024, b) }
+
+// Tests that the precompile hook is invoked with the address, input and cost
+// of an ecrecover call before it is executed.
+func TestOnPrecompileHook(t *testing.T) {
+	defer func(hook func(common.Address, []byte, uint64)) { OnPrecompile = hook }(OnPrecompile)
+
+	type call struct {
+		addr  common.Address
+		input []byte
+		gas   uint64
+	}
+	var calls []call
+	OnPrecompile = func(addr common.Address, input []byte, gas uint64) {
+		calls = append(calls, call{addr, common.CopyBytes(input), gas})
+	}
+	var (
+		addr  = common.BytesToAddress([]byte{1})
+		p     = PrecompiledContracts[addr]
+		input = common.Hex2Bytes("38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+			"000000000000000000000000000000000000000000000000000000000000001b" +
+			"38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+			"789d1dd423d25f0772d2748d60f7e4b81bb14d086eba8e8e8efb6dcff8a4ae02")
+	)
+	// Run with insufficient gas, the hook must still fire before execution
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 0)
+	contract.CodeAddr = &addr
+	if _, _, err := RunPrecompiledContract(p, input, contract, PrecompileContext{}); err != ErrOutOfGas {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrOutOfGas)
+	}
+	contract = NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), params.EcrecoverGas)
+	contract.CodeAddr = &addr
+	if _, _, err := RunPrecompiledContract(p, input, contract, PrecompileContext{}); err != nil {
+		t.Fatalf("failed to run ecrecover: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("hook invocation count mismatch: have %d, want 2", len(calls))
+	}
+	for i, c := range calls {
+		if c.addr != addr {
+			t.Errorf("call %d: address mismatch: have %x, want %x", i, c.addr, addr)
+		}
+		if !bytes.Equal(c.input, input) {
+			t.Errorf("call %d: input mismatch: have %x, want %x", i, c.input, input)
+		}
+		if c.gas != params.EcrecoverGas {
+			t.Errorf("call %d: gas mismatch: have %d, want %d", i, c.gas, params.EcrecoverGas)
+		}
+	}
+}
+
+// Tests that the strict wrapper rejects short ecrecover inputs which are zero
+// padded by the default lenient contract.
+func TestStrictPrecompileEcrecover(t *testing.T) {
+	input := common.Hex2Bytes("38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+		"000000000000000000000000000000000000000000000000000000000000001b")
+
+	lenient := PrecompiledContracts[common.BytesToAddress([]byte{1})]
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), lenient.RequiredGas(input))
+	if _, _, err := RunPrecompiledContract(lenient, input, contract, PrecompileContext{}); err != nil {
+		t.Fatalf("lenient ecrecover failed on padded input: %v", err)
+	}
+	strict := NewStrictPrecompile(lenient)
+	contract = NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), strict.RequiredGas(input))
+	if _, _, err := RunPrecompiledContract(strict, input, contract, PrecompileContext{}); err == nil {
+		t.Fatalf("strict ecrecover accepted a %d byte input", len(input))
+	}
+}
+
+// Tests the input length bounds enforced by the strict wrapper.
+func TestStrictPrecompileLengths(t *testing.T) {
+	tests := []struct {
+		p    PrecompiledContract
+		size int
+		fail bool
+	}{
+		{&ecrecover{}, 128, false},
+		{&ecrecover{}, 129, true},
+		{&bigModexpEIP2565{}, 95, true},
+		{&bigModexpEIP2565{}, 96, false},
+		{&bigModexpEIP2565{}, 200, false},
+		{&bn256Add{}, 64, true},
+		{&bn256Add{}, 128, false},
+		{&bn256ScalarMul{}, 64, true},
+		{&bn256ScalarMul{}, 96, false},
+		{&p256Verify{}, 159, true},
+		{&sha256hash{}, 0, false},
+		{&dataCopy{}, 1, false},
+	}
+	for i, test := range tests {
+		_, err := NewStrictPrecompile(test.p).Run(make([]byte, test.size))
+		if test.fail && err == nil {
+			t.Errorf("test %d: expected failure for %d byte input", i, test.size)
+		}
+		if !test.fail && err != nil {
+			t.Errorf("test %d: unexpected failure for %d byte input: %v", i, test.size, err)
+		}
+	}
+}
+
+// Tests that the strict wrapper forwards the optional extensions of the wrapped
+// contract: its name, its refunds and its execution context.
+func TestStrictPrecompileForwarding(t *testing.T) {
+	if name := NewStrictPrecompile(&ecrecover{}).Name(); name != "ecrecover" {
+		t.Errorf("name mismatch: have %q, want %q", name, "ecrecover")
+	}
+	if name := NewStrictPrecompile(&echoPrecompile{}).Name(); name != "*vm.echoPrecompile" {
+		t.Errorf("unnamed name mismatch: have %q, want %q", name, "*vm.echoPrecompile")
+	}
+	strict := NewStrictPrecompile(&refundingPrecompile{refund: 300})
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 1000)
+	if _, refund, err := RunPrecompiledContract(strict, nil, contract, PrecompileContext{}); err != nil || refund != 300 {
+		t.Errorf("refund mismatch: have %d/%v, want 300/nil", refund, err)
+	}
+	strict = NewStrictPrecompile(&blockPrecompile{})
+	contract = NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 1000)
+	ret, _, err := RunPrecompiledContract(strict, nil, contract, PrecompileContext{BlockNumber: big.NewInt(1234)})
+	if err != nil {
+		t.Fatalf("contextual contract failed: %v", err)
+	}
+	if want := common.LeftPadBytes(big.NewInt(1234).Bytes(), 32); !bytes.Equal(ret, want) {
+		t.Errorf("output mismatch: have %x, want %x", ret, want)
+	}
+}
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 G1 addition.
+func TestPrecompiledBLS12381G1Add(t *testing.T) { testJson("blsG1Add", "0b", t) }
+
+// Tests that the BLS12-381 G1 addition rejects malformed inputs.
+func TestPrecompiledBLS12381G1AddFail(t *testing.T) { testJsonFail("blsG1Add", "0b", t) }
+
+// Tests that the BLS12-381 G1 addition rejects points outside the subgroup.
+func TestPrecompiledBLS12381G1AddSubgroup(t *testing.T) {
+	// (4, y) is on the curve but not in the prime order subgroup
+	point := "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004" +
+		"000000000000000000000000000000000a989badd40d6212b33cffc3f3763e9bc760f988c9926b26da9dd85e928483446346b8ed00e1de5d5ea93e354abe706c"
+	testPrecompiledFailure("0b", precompiledFailureTest{
+		input:         point + point,
+		expectedError: errBLS12381G1PointSubgroup,
+		name:          "g1add_not_in_subgroup",
+	}, t)
+}
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 G1 multi exponentiation.
+func TestPrecompiledBLS12381G1MultiExp(t *testing.T) { testJson("blsG1MultiExp", "0d", t) }
+
+// Tests that the BLS12-381 G1 multi exponentiation rejects malformed inputs.
+func TestPrecompiledBLS12381G1MultiExpFail(t *testing.T) { testJsonFail("blsG1MultiExp", "0d", t) }
+
+// Tests the BLS12-381 G1 multi exponentiation pricing around the boundaries of
+// the discount table.
+func TestPrecompiledBLS12381G1MultiExpGas(t *testing.T) {
+	tests := []struct {
+		k    int
+		want uint64
+	}{
+		{0, math.MaxUint64},
+		{1, 1 * 12000 * 1200 / 1000},
+		{2, 2 * 12000 * 888 / 1000},
+		{127, 127 * 12000 * 175 / 1000},
+		{128, 128 * 12000 * 174 / 1000},
+		{129, 129 * 12000 * 174 / 1000},
+	}
+	p := &bls12381G1MultiExp{}
+	for _, test := range tests {
+		if have := p.RequiredGas(make([]byte, 160*test.k)); have != test.want {
+			t.Errorf("k=%d: gas mismatch: have %d, want %d", test.k, have, test.want)
+		}
+	}
+	testBLS12381MultiExpMalformedGas(p, []int{0, 1, 159, 161, 319}, t)
+}
+
+// testBLS12381MultiExpMalformedGas checks that empty and misaligned inputs of
+// the given multi exponentiation are priced out of reach instead of for free.
+func testBLS12381MultiExpMalformedGas(p PrecompiledContract, sizes []int, t *testing.T) {
+	for _, size := range sizes {
+		input := make([]byte, size)
+		if have := p.RequiredGas(input); have != math.MaxUint64 {
+			t.Errorf("malformed %d byte input: gas mismatch: have %d, want %d", size, have, uint64(math.MaxUint64))
+		}
+		contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 10000000)
+		if _, _, err := RunPrecompiledContract(p, input, contract, PrecompileContext{}); err != ErrOutOfGas {
+			t.Errorf("malformed %d byte input: error mismatch: have %v, want %v", size, err, ErrOutOfGas)
+		}
+	}
+}
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 G2 addition.
+func TestPrecompiledBLS12381G2Add(t *testing.T) { testJson("blsG2Add", "0e", t) }
+
+// Tests that the BLS12-381 G2 addition rejects malformed and off-curve inputs.
+func TestPrecompiledBLS12381G2AddFail(t *testing.T) { testJsonFail("blsG2Add", "0e", t) }
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 G2 scalar multiplication.
+func TestPrecompiledBLS12381G2Mul(t *testing.T) { testJson("blsG2Mul", "0f", t) }
+
+// Tests that the BLS12-381 G2 scalar multiplication rejects malformed and
+// off-curve inputs.
+func TestPrecompiledBLS12381G2MulFail(t *testing.T) { testJsonFail("blsG2Mul", "0f", t) }
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 G2 multi exponentiation.
+func TestPrecompiledBLS12381G2MultiExp(t *testing.T) { testJson("blsG2MultiExp", "10", t) }
+
+// Tests that the BLS12-381 G2 multi exponentiation rejects malformed inputs.
+func TestPrecompiledBLS12381G2MultiExpFail(t *testing.T) { testJsonFail("blsG2MultiExp", "10", t) }
+
+// Tests that the BLS12-381 G2 multi exponentiation rejects points outside of the
+// prime order subgroup.
+func TestPrecompiledBLS12381G2MultiExpSubgroup(t *testing.T) {
+	// (2, y) is on the twist but not in the prime order subgroup
+	point := "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002" +
+		"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" +
+		"00000000000000000000000000000000013a59858b6809fca4d9a3b6539246a70051a3c88899964a42bc9a69cf9acdd9dd387cfa9086b894185b9a46a402be73" +
+		"0000000000000000000000000000000002d27e0ec3356299a346a09ad7dc4ef68a483c3aed53f9139d2f929a3eecebf72082e5e58c6da24ee32e03040c406d4f"
+	scalar := "0000000000000000000000000000000000000000000000000000000000000011"
+
+	testPrecompiledFailure("10", precompiledFailureTest{
+		input:         point + scalar,
+		expectedError: errBLS12381G2PointSubgroup,
+		name:          "g2multiexp_not_in_subgroup",
+	}, t)
+}
+
+// Tests the BLS12-381 G2 multi exponentiation pricing, sharing the discount
+// table of G1 and capping the discount from 128 pairs on.
+func TestPrecompiledBLS12381G2MultiExpGas(t *testing.T) {
+	tests := []struct {
+		k    int
+		want uint64
+	}{
+		{0, math.MaxUint64},
+		{1, 1 * 45000 * 1200 / 1000},
+		{2, 2 * 45000 * 888 / 1000},
+		{128, 128 * 45000 * 174 / 1000},
+		{129, 129 * 45000 * 174 / 1000},
+		{256, 256 * 45000 * 174 / 1000},
+	}
+	p := &bls12381G2MultiExp{}
+	for _, test := range tests {
+		if have := p.RequiredGas(make([]byte, 288*test.k)); have != test.want {
+			t.Errorf("k=%d: gas mismatch: have %d, want %d", test.k, have, test.want)
+		}
+	}
+	testBLS12381MultiExpMalformedGas(p, []int{0, 1, 287, 289, 575}, t)
+}
+
+// Tests the EIP-2537 reference inputs of the BLS12-381 pairing check.
+func TestPrecompiledBLS12381Pairing(t *testing.T) { testJson("blsPairing", "11", t) }
+
+// Tests that the BLS12-381 pairing check rejects malformed inputs.
+func TestPrecompiledBLS12381PairingFail(t *testing.T) { testJsonFail("blsPairing", "11", t) }
+
+// Tests that an empty BLS12-381 pairing check succeeds, the product of zero
+// pairings being the identity, and that it is charged the base price only.
+func TestPrecompiledBLS12381PairingEmpty(t *testing.T) {
+	testPrecompiled("11", precompiledTest{
+		input:    "",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		gas:      65000,
+		name:     "bls_pairing_empty_input",
+	}, t)
+}
+
+// Tests the BLS12-381 field element to G1 mapping against the reference vectors.
+func TestPrecompiledBLS12381MapG1(t *testing.T) { testJson("blsMapG1", "12", t) }
+
+// Tests that the BLS12-381 G1 mapping rejects malformed and non-canonical inputs.
+func TestPrecompiledBLS12381MapG1Fail(t *testing.T) { testJsonFail("blsMapG1", "12", t) }
+
+// Tests the BLS12-381 field element to G2 mapping against the reference vectors.
+func TestPrecompiledBLS12381MapG2(t *testing.T) { testJson("blsMapG2", "13", t) }
+
+// Tests that the BLS12-381 G2 mapping rejects malformed and non-canonical inputs.
+func TestPrecompiledBLS12381MapG2Fail(t *testing.T) { testJsonFail("blsMapG2", "13", t) }
+
+// modexpInput encodes a modexp call of base^exp % mod.
+func modexpInput(base, exp, mod []byte) []byte {
+	in := append(common.LeftPadBytes(big.NewInt(int64(len(base))).Bytes(), 32), common.LeftPadBytes(big.NewInt(int64(len(exp))).Bytes(), 32)...)
+	in = append(in, common.LeftPadBytes(big.NewInt(int64(len(mod))).Bytes(), 32)...)
+	in = append(in, base...)
+	in = append(in, exp...)
+	return append(in, mod...)
+}
+
+// Tests that concurrent modexp calls sharing the scratch integer pool don't
+// interfere with each other. Meant to be run with the race detector.
+func TestModexpPoolConcurrent(t *testing.T) {
+	var (
+		p    = &bigModexpEIP2565{}
+		pend sync.WaitGroup
+	)
+	for i := 0; i < 16; i++ {
+		pend.Add(1)
+		go func(i int) {
+			defer pend.Done()
+
+			base := big.NewInt(int64(i + 2))
+			mod := new(big.Int).Lsh(common.Big1, uint(64+32*i))
+			mod.Sub(mod, big.NewInt(int64(2*i+1)))
+			in := modexpInput(base.Bytes(), []byte{0xff, 0xff}, mod.Bytes())
+
+			want := common.LeftPadBytes(new(big.Int).Exp(base, big.NewInt(0xffff), mod).Bytes(), len(mod.Bytes()))
+			for j := 0; j < 64; j++ {
+				have, err := p.Run(in)
+				if err != nil {
+					t.Errorf("worker %d: run failed: %v", i, err)
+					return
+				}
+				if !bytes.Equal(have, want) {
+					t.Errorf("worker %d: result mismatch: have %x, want %x", i, have, want)
+					return
+				}
+			}
+		}(i)
+	}
+	pend.Wait()
+}
+
+// benchmarkModexp benchmarks an exponentiation with base and modulus of the given
+// bit length, raised to an exponent of expBits bits.
+func benchmarkModexp(bits, expBits int, b *testing.B) {
+	var (
+		p    = &bigModexpEIP2565{}
+		base = bytes.Repeat([]byte{0xab}, bits/8)
+		exp  = bytes.Repeat([]byte{0xcd}, expBits/8)
+		mod  = bytes.Repeat([]byte{0xef}, bits/8)
+		in   = modexpInput(base, exp, mod)
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Run(in)
+	}
+}
+
+func BenchmarkModexp256(b *testing.B)      { benchmarkModexp(256, 256, b) }
+func BenchmarkModexp1024(b *testing.B)     { benchmarkModexp(1024, 1024, b) }
+func BenchmarkModexp4096(b *testing.B)     { benchmarkModexp(4096, 256, b) }
+func BenchmarkModexp4096Full(b *testing.B) { benchmarkModexp(4096, 4096, b) }
+
+// ecrecoverCacheInput is a valid ecrecover input signed by ecrecoverCacheOutput.
+var (
+	ecrecoverCacheInput = common.Hex2Bytes("38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+		"000000000000000000000000000000000000000000000000000000000000001b" +
+		"38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e" +
+		"789d1dd423d25f0772d2748d60f7e4b81bb14d086eba8e8e8efb6dcff8a4ae02")
+	ecrecoverCacheOutput = common.Hex2Bytes("000000000000000000000000ceaccac640adf55b2028469bd36ba501f28b699d")
+)
+
+// Tests that cached ecrecover results are identical to freshly recovered ones
+// and can't be corrupted through the returned slices.
+func TestEcrecoverCache(t *testing.T) {
+	SetEcrecoverCacheSize(16)
+	defer SetEcrecoverCacheSize(0)
+
+	p := &ecrecover{}
+	for i := 0; i < 3; i++ {
+		have, err := p.Run(ecrecoverCacheInput)
+		if err != nil {
+			t.Fatalf("run %d: failed to recover: %v", i, err)
+		}
+		if !bytes.Equal(have, ecrecoverCacheOutput) {
+			t.Fatalf("run %d: output mismatch: have %x, want %x", i, have, ecrecoverCacheOutput)
+		}
+		// Scribble over the result, the cached entry must stay intact
+		for j := range have {
+			have[j] = 0xff
+		}
+	}
+	// Invalid signatures are cached as failures
+	invalid := common.CopyBytes(ecrecoverCacheInput)
+	invalid[63] = 0
+	for i := 0; i < 2; i++ {
+		if have, err := p.Run(invalid); have != nil || err != nil {
+			t.Errorf("run %d: invalid signature: have %x/%v, want nil/nil", i, have, err)
+		}
+	}
+	// Disabling the cache must fall back to plain recovery
+	SetEcrecoverCacheSize(0)
+	if have, _ := p.Run(ecrecoverCacheInput); !bytes.Equal(have, ecrecoverCacheOutput) {
+		t.Errorf("uncached output mismatch: have %x, want %x", have, ecrecoverCacheOutput)
+	}
+}
+
+func benchmarkEcrecover(cache int, b *testing.B) {
+	SetEcrecoverCacheSize(cache)
+	defer SetEcrecoverCacheSize(0)
+
+	p := &ecrecover{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Run(ecrecoverCacheInput)
+	}
+}
+
+func BenchmarkEcrecoverUncached(b *testing.B) { benchmarkEcrecover(0, b) }
+func BenchmarkEcrecoverCached(b *testing.B)   { benchmarkEcrecover(16, b) }
+
+// Tests that ecrecover rejects any v value other than 27 and 28 with an empty
+// output and no error.
+func TestEcrecoverInvalidV(t *testing.T) {
+	p := &ecrecover{}
+	for _, v := range []byte{0, 1, 26, 29, 255} {
+		in := common.CopyBytes(ecrecoverCacheInput)
+		in[63] = v
+		if have, err := p.Run(in); len(have) != 0 || err != nil {
+			t.Errorf("v=%d: have %x/%v, want empty output and no error", v, have, err)
+		}
+	}
+	// Both canonical values must reach the recovery
+	for _, v := range []byte{27, 28} {
+		in := common.CopyBytes(ecrecoverCacheInput)
+		in[63] = v
+		if have, err := p.Run(in); len(have) != 32 || err != nil {
+			t.Errorf("v=%d: have %x/%v, want recovered address", v, have, err)
+		}
+	}
+}
+
+// benchmarkPrecompiled benchmarks pricing and running the builtin contract at
+// the given address on the given input.
+func benchmarkPrecompiled(addr string, input []byte, b *testing.B) {
+	p := allPrecompiles[common.HexToAddress(addr)]
+
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.RequiredGas(input)
+		if _, err := p.Run(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEcrecover(b *testing.B) { benchmarkPrecompiled("01", ecrecoverCacheInput, b) }
+func BenchmarkSha256(b *testing.B)    { benchmarkPrecompiled("02", make([]byte, 1024), b) }
+func BenchmarkRipemd160(b *testing.B) { benchmarkPrecompiled("03", make([]byte, 1024), b) }
+func BenchmarkIdentity(b *testing.B)  { benchmarkPrecompiled("04", make([]byte, 1024), b) }
+
+// Tests that the raw RIPEMD-160 digest matches the reference vectors and the
+// padded output of the precompiled contract.
+func TestRipemd160Raw(t *testing.T) {
+	tests := []struct {
+		input, digest string
+	}{
+		{"", "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+		{"abc", "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+		{"message digest", "5d0689ef49d2fae572b881b123a85ffa21595f36"},
+	}
+	for _, test := range tests {
+		raw := Ripemd160Raw([]byte(test.input))
+		if have := common.Bytes2Hex(raw); have != test.digest {
+			t.Errorf("%q: digest mismatch: have %s, want %s", test.input, have, test.digest)
+		}
+		padded, err := (&ripemd160hash{}).Run([]byte(test.input))
+		if err != nil {
+			t.Fatalf("%q: precompile failed: %v", test.input, err)
+		}
+		if want := common.LeftPadBytes(raw, 32); !bytes.Equal(padded, want) {
+			t.Errorf("%q: padded output mismatch: have %x, want %x", test.input, padded, want)
+		}
+	}
+}
+
+// Tests that modexp inputs declaring operands far longer than any block could
+// pay for neither run through the EVM nor allocate for them when run directly,
+// including lengths whose price does not saturate.
+func TestModexpHugeLength(t *testing.T) {
+	// modexpInput assembles an input declaring the given operand lengths, with a
+	// few bytes of actual operands
+	modexpInput := func(baseLen, expLen, modLen *big.Int) []byte {
+		in := make([]byte, 96)
+		copy(in[0:32], common.BigToHash(baseLen).Bytes())
+		copy(in[32:64], common.BigToHash(expLen).Bytes())
+		copy(in[64:96], common.BigToHash(modLen).Bytes())
+		return append(in, 3, 5, 7)
+	}
+	var (
+		one  = big.NewInt(1)
+		huge = func(bits uint) *big.Int { return new(big.Int).Lsh(common.Big1, bits) }
+	)
+	tests := []struct {
+		baseLen, expLen, modLen *big.Int
+	}{
+		{one, one, huge(32)},
+		{one, one, huge(34)},
+		{one, one, huge(40)},
+		{huge(32), one, one},
+		{huge(34), one, one},
+		{one, huge(33), one},
+		{one, huge(40), one},
+	}
+	p := &bigModexpEIP2565{}
+	for i, test := range tests {
+		in := modexpInput(test.baseLen, test.expLen, test.modLen)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), 10000000)
+		if _, _, err := RunPrecompiledContract(p, in, contract, PrecompileContext{}); err != ErrOutOfGas {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, ErrOutOfGas)
+		}
+		if _, err := p.Run(in); err != errModexpUnpayable {
+			t.Errorf("test %d: direct run error mismatch: have %v, want %v", i, err, errModexpUnpayable)
+		}
+		runtime.ReadMemStats(&after)
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1024*1024 {
+			t.Errorf("test %d: allocated %d bytes for an unpayable call", i, alloc)
+		}
+	}
+	// Long exponents within the bound are payable in theory, but running off the
+	// end of the input they leave the modulus zero and must not be allocated
+	in := modexpInput(one, huge(31), one)
+	in[96], in[97], in[len(in)-1] = 2, 1, 4
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if out, err := p.Run(in); err != nil || !bytes.Equal(out, []byte{0}) {
+		t.Errorf("long exponent: have %x/%v, want 00/nil", out, err)
+	}
+	runtime.ReadMemStats(&after)
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1024*1024 {
+		t.Errorf("long exponent: allocated %d bytes for the padding", alloc)
+	}
+}
+
+// Tests that modexp is priced on the adjusted exponent length, i.e. the index
+// of the highest set bit in the leading 32 exponent bytes plus 8 per byte after
+// them, using the worked examples of EIP-198 repriced under EIP-2565.
+func TestModexpAdjustedExponentLength(t *testing.T) {
+	var (
+		p     = &bigModexpEIP2565{}
+		mod   = common.Hex2Bytes("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+		fermt = common.Hex2Bytes("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2d")
+	)
+	tests := []struct {
+		name string
+		exp  []byte
+		gas  uint64 // ceil(32/8)^2 * max(adjExpLen, 1) / 3, floored at 200
+	}{
+		// EIP-198 examples: 3^(p-2) % p and 0^(p-2) % p, adjusted length 255
+		{"eip198-fermat", fermt, 16 * 255 / 3},
+		{"zero-exponent", make([]byte, 32), 200},
+		{"unit-exponent", common.LeftPadBytes([]byte{1}, 32), 200},
+		{"short-exponent", []byte{0xff}, 200},
+		{"high-bit", append([]byte{0x80}, make([]byte, 31)...), 16 * 255 / 3},
+		{"long-zero-head", make([]byte, 64), 16 * 256 / 3},
+		{"long-unit-head", append(common.LeftPadBytes([]byte{1}, 32), make([]byte, 32)...), 16 * 256 / 3},
+		{"long-high-head", append(bytes.Repeat([]byte{0xff}, 32), make([]byte, 32)...), 16 * (256 + 255) / 3},
+	}
+	for _, test := range tests {
+		for _, base := range [][]byte{{3}, {0}} {
+			if have := p.RequiredGas(modexpInput(base, test.exp, mod)); have != test.gas {
+				t.Errorf("%s: base %x: gas mismatch: have %d, want %d", test.name, base, have, test.gas)
+			}
+		}
+	}
+}
+
+// Tests that the word-wise zero check agrees with the bytewise one for every
+// length and position of a single set byte.
+func TestAllZeroWords(t *testing.T) {
+	for size := 0; size <= 40; size++ {
+		b := make([]byte, size)
+		if !allZeroWords(b) {
+			t.Errorf("size %d: zero slice reported non-zero", size)
+		}
+		for i := range b {
+			b[i] = 1
+			if allZeroWords(b) {
+				t.Errorf("size %d: byte %d set but reported zero", size, i)
+			}
+			b[i] = 0
+		}
+	}
+}
+
+// Tests that ecrecover rejects zero r or s values with an empty output and no
+// error.
+func TestEcrecoverZeroRS(t *testing.T) {
+	p := &ecrecover{}
+	for _, off := range []int{64, 96} {
+		in := common.CopyBytes(ecrecoverCacheInput)
+		copy(in[off:off+32], make([]byte, 32))
+		if have, err := p.Run(in); len(have) != 0 || err != nil {
+			t.Errorf("zero word at %d: have %x/%v, want empty output and no error", off, have, err)
+		}
+	}
+}
+
+// BenchmarkEcrecoverZeroR measures rejecting a signature with a zero r value.
+func BenchmarkEcrecoverZeroR(b *testing.B) {
+	in := common.CopyBytes(ecrecoverCacheInput)
+	copy(in[64:96], make([]byte, 32))
+	benchmarkPrecompiled("01", in, b)
+}
+
+// Tests that batch recovery returns, in order, exactly the outputs of running
+// the ecrecover precompile on each input.
+func TestEcrecoverBatch(t *testing.T) {
+	var inputs [][]byte
+	for i := 0; i < 64; i++ {
+		in := common.CopyBytes(ecrecoverCacheInput)
+		switch i % 4 {
+		case 1:
+			in[63] = 28 // other recovery id
+		case 2:
+			in[0] ^= byte(i) // different hash, different signer
+		case 3:
+			in[63] = 29 // invalid v
+		}
+		inputs = append(inputs, in)
+	}
+	inputs = append(inputs, nil, []byte{1, 2, 3})
+
+	have := EcrecoverBatch(inputs)
+	if len(have) != len(inputs) {
+		t.Fatalf("output count mismatch: have %d, want %d", len(have), len(inputs))
+	}
+	for i, in := range inputs {
+		want, _ := (&ecrecover{}).Run(common.CopyBytes(in))
+		if !bytes.Equal(have[i], want) {
+			t.Errorf("input %d: output mismatch: have %x, want %x", i, have[i], want)
+		}
+	}
+	if len(EcrecoverBatch(nil)) != 0 {
+		t.Errorf("empty batch produced outputs")
+	}
+}
+
+// Tests that the parallel bn256 pairing check agrees with the precompile on
+// every valid and invalid reference input.
+func TestBn256PairingCheckParallel(t *testing.T) {
+	for _, test := range bn256PairingTests {
+		in := common.Hex2Bytes(test.input)
+		want, _ := (&bn256Pairing{}).Run(in)
+		have, err := Bn256PairingCheckParallel(in)
+		if err != nil {
+			t.Errorf("%s: parallel check failed: %v", test.name, err)
+		}
+		if !bytes.Equal(have, want) {
+			t.Errorf("%s: output mismatch: have %x, want %x", test.name, have, want)
+		}
+	}
+	for _, test := range bn256PairingInvalidTests {
+		if _, err := Bn256PairingCheckParallel(common.Hex2Bytes(test.input)); err == nil {
+			t.Errorf("%s: parallel check accepted invalid input", test.name)
+		}
+	}
+}
+
+// bn256PairingTenPairs returns a valid pairing input of ten pairs, built out of
+// the largest reference input.
+func bn256PairingTenPairs() []byte {
+	var in []byte
+	for _, test := range bn256PairingTests {
+		if data := common.Hex2Bytes(test.input); len(data) > len(in) {
+			in = data
+		}
+	}
+	for len(in) < 10*192 {
+		in = append(in, in[:192]...)
+	}
+	return in[:10*192]
+}
+
+func BenchmarkBn256PairingSequential(b *testing.B) {
+	in := bn256PairingTenPairs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		(&bn256Pairing{}).Run(in)
+	}
+}
+
+func BenchmarkBn256PairingParallel(b *testing.B) {
+	in := bn256PairingTenPairs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Bn256PairingCheckParallel(in)
+	}
+}
+
+// Tests that the bn256 precompiles reject field element coordinates that are
+// not reduced modulo the field prime, both when equal to the prime and when
+// congruent to an otherwise valid coordinate, instead of silently reducing them.
+func TestPrecompiledBn256NonCanonical(t *testing.T) {
+	tests := []struct {
+		addr    string
+		input   string
+		offsets []int
+	}{
+		{"06", bn256AddTests[0].input, []int{0, 32, 64, 96}},
+		{"07", bn256ScalarMulTests[0].input, []int{0, 32}},
+		{"08", bn256PairingTests[0].input[:384], []int{0, 32, 64, 96, 128, 160}},
+	}
+	for _, test := range tests {
+		p := PrecompiledContractsByzantium[common.HexToAddress(test.addr)]
+		valid := common.Hex2Bytes(test.input)
+		if _, err := p.Run(valid); err != nil {
+			t.Fatalf("%s: reference input rejected: %v", test.addr, err)
+		}
+		for _, off := range test.offsets {
+			coord := new(big.Int).SetBytes(valid[off : off+32])
+			for _, bad := range []*big.Int{bn256.P, new(big.Int).Add(coord, bn256.P)} {
+				in := common.CopyBytes(valid)
+				copy(in[off:off+32], common.LeftPadBytes(bad.Bytes(), 32))
+				if res, err := p.Run(in); err != errBN256NonCanonical {
+					t.Errorf("%s: coordinate %x at offset %d: have %x/%v, want error %v", test.addr, bad, off, res, err, errBN256NonCanonical)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// +build 386 arm mips mipsle
+
+package vm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the word based gas calculations saturate instead of wrapping for
+// input lengths near the maximum int size on 32 bit platforms.
+func TestWordGasOverflow(t *testing.T) {
+	const size = math.MaxInt32
+
+	// ceil(MaxInt32 / 32) words, which must not wrap around as int arithmetic would
+	words := uint64(size)/32 + 1
+	if have, want := wordGas(size, params.Ripemd160WordGas, params.Ripemd160Gas), words*params.Ripemd160WordGas+params.Ripemd160Gas; have != want {
+		t.Errorf("ripemd160: gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := wordGas(size, params.Sha256WordGas, params.Sha256Gas), words*params.Sha256WordGas+params.Sha256Gas; have != want {
+		t.Errorf("sha256: gas mismatch: have %d, want %d", have, want)
+	}
+	if have, want := wordGas(size, params.IdentityWordGas, params.IdentityGas), words*params.IdentityWordGas+params.IdentityGas; have != want {
+		t.Errorf("identity: gas mismatch: have %d, want %d", have, want)
+	}
+	// Oversized prices must saturate instead of wrapping
+	if have := wordGas(size, 1<<40, 0); have != math.MaxUint64 {
+		t.Errorf("multiplication: gas mismatch: have %d, want %d", have, uint64(math.MaxUint64))
+	}
+	if have := wordGas(32, math.MaxUint64, 1); have != math.MaxUint64 {
+		t.Errorf("addition: gas mismatch: have %d, want %d", have, uint64(math.MaxUint64))
+	}
+}
@@ -68,6 +68,11 @@ type EVM struct {
 
 	// chainConfig contains information about the current chain
 	chainConfig *params.ChainConfig
+	// chainRules contains the chain rules for the current epoch
+	chainRules params.Rules
+	// precompiles contains the builtin native contracts active under the
+	// chain rules of the current epoch
+	precompiles map[common.Address]PrecompiledContract
 	// virtual machine configuration options used to initialise the
 	// evm.
 	vmConfig Config
@@ -86,12 +91,20 @@ func NewEVM(ctx Context, statedb StateDB, chainConfig *params.ChainConfig, vmCon
 		StateDB:     statedb,
 		vmConfig:    vmConfig,
 		chainConfig: chainConfig,
+		chainRules:  chainConfig.Rules(ctx.BlockNumber),
 	}
+	evm.precompiles = PrecompiledContractsForConfig(evm.chainRules)
 
 	evm.interpreter = NewInterpreter(evm, vmConfig)
 	return evm
 }
 
+// precompile returns the native contract at the given address active in the
+// current epoch, builtin or registered.
+func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
+	return lookupPrecompile(evm.precompiles, addr)
+}
+
 // Cancel cancels any running EVM operation. This may be called concurrently and it's safe to be
 // called multiple times.
 func (evm *EVM) Cancel() {
@@ -120,7 +133,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) {
-		if PrecompiledContracts[addr] == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
+		if _, ok := evm.precompile(addr); !ok && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
 			return nil, gas, nil
 		}
 
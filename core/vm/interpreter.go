@@ -86,8 +86,18 @@ func (evm *Interpreter) Run(contract *Contract, input []byte) (ret []byte, err e
 	defer func() { evm.env.depth-- }()
 
 	if contract.CodeAddr != nil {
-		if p := PrecompiledContracts[*contract.CodeAddr]; p != nil {
-			return RunPrecompiledContract(p, input, contract)
+		if p, ok := evm.env.precompile(*contract.CodeAddr); ok {
+			ctx := PrecompileContext{
+				BlockNumber: evm.env.BlockNumber,
+				Time:        evm.env.Time,
+				ChainId:     evm.env.ChainConfig().ChainId,
+				State:       evm.env.StateDB,
+			}
+			ret, refund, err := RunPrecompiledContract(p, input, contract, ctx)
+			if refund > 0 {
+				evm.env.StateDB.AddRefund(new(big.Int).SetUint64(refund))
+			}
+			return ret, err
 		}
 	}
 
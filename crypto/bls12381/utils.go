@@ -0,0 +1,77 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls12381
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func bigFromHex(hex string) *big.Int {
+	return new(big.Int).SetBytes(common.FromHex(hex))
+}
+
+// decodeFieldElement expects 64 byte input with zero top 16 bytes,
+// returns lower 48 bytes.
+func decodeFieldElement(in []byte) ([]byte, error) {
+	if len(in) != 64 {
+		return nil, errors.New("invalid field element length")
+	}
+	// check top bytes
+	for i := 0; i < 16; i++ {
+		if in[i] != byte(0x00) {
+			return nil, errors.New("invalid field element top bytes")
+		}
+	}
+	out := make([]byte, 48)
+	copy(out[:], in[16:])
+	return out, nil
+}
+
+// Flags carried by the most significant bits of a point in the compressed
+// zcash serialization format.
+const (
+	compressionFlag = byte(1 << 7)
+	infinityFlag    = byte(1 << 6)
+	signFlag        = byte(1 << 5)
+)
+
+func isAllZero(in []byte) bool {
+	for _, b := range in {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isLexicographicallyLargest reports whether the given field element is
+// larger than its negation, i.e. greater than (p-1)/2.
+func isLexicographicallyLargest(a *fe) bool {
+	return toBig(a).Cmp(pMinus1Over2) > 0
+}
+
+// isLexicographicallyLargest2 reports whether the given extension field
+// element is larger than its negation, comparing the c1 coefficient first.
+func isLexicographicallyLargest2(a *fe2) bool {
+	if !a[1].isZero() {
+		return isLexicographicallyLargest(&a[1])
+	}
+	return isLexicographicallyLargest(&a[0])
+}
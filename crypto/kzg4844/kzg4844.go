@@ -0,0 +1,153 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package kzg4844 implements the KZG point evaluation proof verification
+// of EIP-4844.
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// Commitment is a serialized commitment to a polynomial.
+type Commitment [48]byte
+
+// Proof is a serialized commitment to the quotient polynomial.
+type Proof [48]byte
+
+// Point is a BLS field element.
+type Point [32]byte
+
+// Claim is a claimed evaluation value in a specific point.
+type Claim [32]byte
+
+const (
+	// FieldElementsPerBlob is the number of field elements in a blob.
+	FieldElementsPerBlob = 4096
+
+	// BlobCommitmentVersionKZG is the version byte of a KZG versioned hash.
+	BlobCommitmentVersionKZG = byte(0x01)
+)
+
+// BLSModulus is the order of the BLS12-381 scalar field.
+var BLSModulus = common.FromHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+
+var (
+	errInvalidFieldElement = errors.New("kzg: field element not canonical")
+	errInvalidProof        = errors.New("kzg: invalid proof")
+	errNotInSubgroup       = errors.New("kzg: point not in the correct subgroup")
+)
+
+var (
+	setupOnce sync.Once
+	setupG2   []*bls12381.PointG2
+	setupErr  error
+)
+
+// loadTrustedSetup decodes the G2 points of the trusted setup. It is safe to
+// call concurrently, the setup is only ever decoded once.
+func loadTrustedSetup() ([]*bls12381.PointG2, error) {
+	setupOnce.Do(func() {
+		g2 := bls12381.NewG2()
+		points := make([]*bls12381.PointG2, len(trustedSetupG2))
+		for i, enc := range trustedSetupG2 {
+			p, err := g2.FromCompressed(common.FromHex(enc))
+			if err != nil {
+				setupErr = err
+				return
+			}
+			points[i] = p
+		}
+		setupG2 = points
+	})
+	return setupG2, setupErr
+}
+
+// CalcBlobHashV1 calculates the 'versioned blob hash' of a commitment.
+func CalcBlobHashV1(commit *Commitment) (vh [32]byte) {
+	vh = sha256.Sum256(commit[:])
+	vh[0] = BlobCommitmentVersionKZG
+	return vh
+}
+
+// VerifyProof verifies the KZG proof that the polynomial represented by the
+// commitment evaluates to the claim at the given point.
+func VerifyProof(commitment Commitment, point Point, claim Claim, proof Proof) error {
+	setup, err := loadTrustedSetup()
+	if err != nil {
+		return err
+	}
+	engine := bls12381.NewPairingEngine()
+
+	z, err := decodeScalar(point[:], engine.G1.Q())
+	if err != nil {
+		return err
+	}
+	y, err := decodeScalar(claim[:], engine.G1.Q())
+	if err != nil {
+		return err
+	}
+	c, err := decodeG1(engine.G1, commitment[:])
+	if err != nil {
+		return err
+	}
+	pi, err := decodeG1(engine.G1, proof[:])
+	if err != nil {
+		return err
+	}
+	// Verify e(C - [y]G1, G2) == e(proof, [tau]G2 - [z]G2)
+	yG1 := engine.G1.MulScalar(engine.G1.New(), engine.G1.One(), y)
+	engine.G1.Sub(c, c, yG1)
+
+	zG2 := engine.G2.MulScalar(engine.G2.New(), engine.G2.One(), z)
+	tauMinusZ := engine.G2.Sub(engine.G2.New(), setup[1], zG2)
+
+	engine.AddPair(c, engine.G2.One())
+	engine.AddPairInv(pi, tauMinusZ)
+	if !engine.Check() {
+		return errInvalidProof
+	}
+	return nil
+}
+
+// decodeScalar decodes a big endian scalar field element, rejecting values
+// which are not reduced modulo the field order.
+func decodeScalar(in []byte, order *big.Int) (*big.Int, error) {
+	s := new(big.Int).SetBytes(in)
+	if s.Cmp(order) >= 0 {
+		return nil, errInvalidFieldElement
+	}
+	return s, nil
+}
+
+// decodeG1 decodes a compressed G1 point, making sure it is in the correct
+// subgroup.
+func decodeG1(g1 *bls12381.G1, in []byte) (*bls12381.PointG1, error) {
+	p, err := g1.FromCompressed(in)
+	if err != nil {
+		return nil, err
+	}
+	if !g1.InCorrectSubgroup(p) {
+		return nil, errNotInSubgroup
+	}
+	return p, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg4844
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testVersionedHash = common.FromHex("01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b")
+	testPoint         = common.FromHex("564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d36306")
+	testClaim         = common.FromHex("24d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a1")
+	testCommitment    = common.FromHex("8f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481bc22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7")
+	testProof         = common.FromHex("873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a")
+)
+
+func TestLoadTrustedSetup(t *testing.T) {
+	setup, err := loadTrustedSetup()
+	if err != nil {
+		t.Fatalf("failed to load trusted setup: %v", err)
+	}
+	if len(setup) != len(trustedSetupG2) {
+		t.Fatalf("setup size mismatch: have %d, want %d", len(setup), len(trustedSetupG2))
+	}
+}
+
+func TestCalcBlobHashV1(t *testing.T) {
+	var commitment Commitment
+	copy(commitment[:], testCommitment)
+
+	if vh := CalcBlobHashV1(&commitment); !bytes.Equal(vh[:], testVersionedHash) {
+		t.Fatalf("versioned hash mismatch: have %x, want %x", vh, testVersionedHash)
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	var (
+		commitment Commitment
+		point      Point
+		claim      Claim
+		proof      Proof
+	)
+	copy(commitment[:], testCommitment)
+	copy(point[:], testPoint)
+	copy(claim[:], testClaim)
+	copy(proof[:], testProof)
+
+	if err := VerifyProof(commitment, point, claim, proof); err != nil {
+		t.Fatalf("failed to verify valid proof: %v", err)
+	}
+	claim[31] ^= 1
+	if err := VerifyProof(commitment, point, claim, proof); err != errInvalidProof {
+		t.Fatalf("tampered claim error mismatch: have %v, want %v", err, errInvalidProof)
+	}
+	claim[31] ^= 1
+	copy(point[:], BLSModulus)
+	if err := VerifyProof(commitment, point, claim, proof); err != errInvalidFieldElement {
+		t.Fatalf("non-canonical point error mismatch: have %v, want %v", err, errInvalidFieldElement)
+	}
+}
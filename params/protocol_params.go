@@ -60,9 +60,33 @@ const (
 	MemoryGas        uint64 = 3     // Times the address of the (highest referenced byte in memory + 1). NOTE: referencing happens on read, write and in instructions such as RETURN and CALL.
 	TxDataNonZeroGas uint64 = 68    // Per byte of data attached to a transaction that is not equal to zero. NOTE: Not payable on data of calls between transactions.
 
+	ModExpQuadCoeffDiv        uint64 = 20     // Divisor for the quadratic particle of the big int modular exponentiation
+	Bn256AddGas               uint64 = 500    // Gas needed for an elliptic curve addition
+	Bn256ScalarMulGas         uint64 = 40000  // Gas needed for an elliptic curve scalar multiplication
+	Bn256PairingBaseGas       uint64 = 100000 // Base price for an elliptic curve pairing check
+	Bn256PairingPerPointGas   uint64 = 80000  // Per-point price for an elliptic curve pairing check
+	BlobVerifyGas             uint64 = 50000  // Gas needed for a KZG point evaluation
+	Bls12381G1AddGas          uint64 = 500    // Price for BLS12-381 elliptic curve G1 point addition
+	Bls12381G1MulGas          uint64 = 12000  // Price for BLS12-381 elliptic curve G1 point scalar multiplication
+	Bls12381G2AddGas          uint64 = 800    // Price for BLS12-381 elliptic curve G2 point addition
+	Bls12381G2MulGas          uint64 = 45000  // Price for BLS12-381 elliptic curve G2 point scalar multiplication
+	Bls12381PairingBaseGas    uint64 = 65000  // Base price for a BLS12-381 elliptic curve pairing check
+	Bls12381PairingPerPairGas uint64 = 43000  // Per-pair price for a BLS12-381 elliptic curve pairing check
+	Bls12381MapG1Gas          uint64 = 5500   // Gas price for BLS12-381 mapping field element to G1 operation
+	Bls12381MapG2Gas          uint64 = 75000  // Gas price for BLS12-381 mapping field element to G2 operation
+	P256VerifyGas             uint64 = 3450   // Gas needed for a secp256r1 signature verification
+	Ed25519VerifyGas          uint64 = 2000   // Base price for an ed25519 signature verification
+	Ed25519VerifyWordGas      uint64 = 12     // Per-word price of the message of an ed25519 signature verification
+
+	Bls12381MultiExpDiscountMultiplier uint64 = 1000 // Divisor of the BLS12-381 multi exponentiation discounts
+
 	MaxCodeSize = 24576
 )
 
+// Bls12381MultiExpDiscountTable is the gas discount table for BLS12-381 G1 and G2
+// multi exponentiation operations, indexed by the number of pairs minus one.
+var Bls12381MultiExpDiscountTable = [128]uint64{1200, 888, 764, 641, 594, 547, 500, 453, 438, 423, 408, 394, 379, 364, 349, 334, 330, 326, 322, 318, 314, 310, 306, 302, 298, 294, 289, 285, 281, 277, 273, 269, 268, 266, 265, 263, 262, 260, 259, 257, 256, 254, 253, 251, 250, 248, 247, 245, 244, 242, 241, 239, 238, 236, 235, 233, 232, 231, 229, 228, 226, 225, 223, 222, 221, 220, 219, 219, 218, 217, 216, 216, 215, 214, 213, 213, 212, 211, 211, 210, 209, 208, 208, 207, 206, 205, 205, 204, 203, 202, 202, 201, 200, 199, 199, 198, 197, 196, 196, 195, 194, 193, 193, 192, 191, 191, 190, 189, 188, 188, 187, 186, 185, 185, 184, 183, 182, 182, 181, 180, 179, 179, 178, 177, 176, 176, 175, 174}
+
 var (
 	GasLimitBoundDivisor   = big.NewInt(1024)                  // The bound divisor of the gas limit, used in update calculations.
 	MinGasLimit            = big.NewInt(5000)                  // Minimum the gas limit may ever be.
@@ -228,6 +228,7 @@ func RunVm(statedb *state.StateDB, env, exec map[string]string) ([]byte, []*type
 	)
 	caller := statedb.GetOrNewStateObject(from)
 	vm.PrecompiledContracts = make(map[common.Address]vm.PrecompiledContract)
+	vm.PrecompiledContractsHomestead = make(map[common.Address]vm.PrecompiledContract)
 
 	environment, _ := NewEVMEnvironment(true, chainConfig, statedb, env, exec)
 	ret, g, err := environment.Call(caller, to, data, gas.Uint64(), value)